@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/Yandex-Practicum/tracker/internal/achievements"
+	"github.com/Yandex-Practicum/tracker/internal/journal"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+)
+
+const achievementsUsage = `
+
+Печатает текущую и лучшую серии дней с выполненной целью по шагам,
+личные рекорды и полученные значки за всю историю.`
+
+func runAchievements(a *app, args []string) error {
+	fs := a.flagSet("achievements", achievementsUsage)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageError("лишние аргументы: %v", fs.Args())
+	}
+
+	p, err := a.loadProfile()
+	if err != nil {
+		return err
+	}
+	entries, err := journal.Load(a.journalPath())
+	if err != nil {
+		return err
+	}
+
+	b, err := a.reportBuilder()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(a.stdout, computeAchievements(b, entries, p).Text())
+	return nil
+}
+
+// computeAchievements рассчитывает достижения по всей истории до сегодняшнего дня включительно.
+func computeAchievements(b report.Builder, entries []journal.Entry, p profile.Profile) achievements.Stats {
+	from, to := historyUntilToday(entries, p.Location())
+	return achievements.Compute(b.Build(entries, p, from, to), p.Goal())
+}
+
+// newAchievements возвращает сообщения о достижениях, полученных записью e.
+// Ошибки старых записей уже попадали в журнал, поэтому здесь не пишутся.
+func (a *app) newAchievements(entries []journal.Entry, e journal.Entry) []string {
+	p, err := a.loadProfile()
+	if err != nil {
+		return nil
+	}
+	h, err := a.loadBody()
+	if err != nil {
+		return nil
+	}
+	b := report.NewBuilder(a.cfg, slog.New(slog.NewTextHandler(io.Discard, nil))).WithBody(h)
+
+	before := computeAchievements(b, entries, p)
+	after := computeAchievements(b, append(entries[:len(entries):len(entries)], e), p)
+	return achievements.New(before, after)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Yandex-Practicum/tracker/internal/daysteps"
+	"github.com/Yandex-Practicum/tracker/internal/journal"
+	"github.com/Yandex-Practicum/tracker/internal/meta"
+	"github.com/Yandex-Practicum/tracker/internal/nutrition"
+	"github.com/Yandex-Practicum/tracker/internal/recovery"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+)
+
+const addUsage = `[-at время] steps|training|sleep|rest|food <запись>
+
+Добавляет в журнал пакет дневной активности ("678,0h50m"), тренировку
+("3456,Ходьба,3h00m", "25km,Велосипед,1h10m", "40x25m,Плавание,кроль,0h45m"),
+ночной сон ("23:15,07:05,quality=4": отход ко сну, пробуждение и оценка от 1 до 5),
+запланированный день отдыха ("после полумарафона") или приём пищи
+("Завтрак,520,protein=30,fat=12,carbs=60": ккал и граммы) и печатает сводку записи.
+Сон относится ко дню -at, в который наступило пробуждение. Запись проверяется до сохранения.
+Если запись принесла новую серию, рекорд или значок, о них тоже сообщается.`
+
+func runAdd(a *app, args []string) error {
+	fs := a.flagSet("add", addUsage)
+	at := fs.String("at", "", "время начала: RFC 3339, \"2006-01-02 15:04\" или \"2006-01-02\"; по умолчанию — сейчас")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return usageError("ожидается вид записи и сама запись, получено аргументов: %d", fs.NArg())
+	}
+
+	kind, err := journal.ParseKind(fs.Arg(0))
+	if err != nil {
+		return usageError("%v", err)
+	}
+	t, err := parseTime(*at, a.location())
+	if err != nil {
+		return err
+	}
+	entry := journal.Entry{Time: t, Kind: kind, Record: fs.Arg(1)}
+
+	info, err := a.describe(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := a.ensureDir(); err != nil {
+		return err
+	}
+	entries, err := journal.Load(a.journalPath())
+	if err != nil {
+		return err
+	}
+	if err := journal.Append(a.journalPath(), entry); err != nil {
+		return err
+	}
+
+	fmt.Fprint(a.stdout, info)
+	for _, msg := range a.newAchievements(entries, entry) {
+		fmt.Fprintln(a.stdout, msg)
+	}
+	return nil
+}
+
+// describe проверяет запись и возвращает её сводку с весом и ростом на момент записи.
+func (a *app) describe(e journal.Entry) (string, error) {
+	p, err := a.loadProfile()
+	if err != nil {
+		return "", err
+	}
+	h, err := a.loadBody()
+	if err != nil {
+		return "", err
+	}
+	p = h.At(e.Time, p)
+
+	switch e.Kind {
+	case journal.KindSteps:
+		s, err := daysteps.NewCalculator(a.cfg).WithLogger(a.logger).Summarize(e.Record, p.Weight, p.Height)
+		if err != nil {
+			return "", err
+		}
+		return s.Info(), nil
+	case journal.KindSleep:
+		s, err := recovery.ParseSleep(e.Record, e.Time.In(meta.FromRecord(e.Record).Location(a.location())))
+		if err != nil {
+			return "", err
+		}
+		return s.Info(), nil
+	case journal.KindRest:
+		r, err := recovery.ParseRest(e.Record)
+		if err != nil {
+			return "", err
+		}
+		return r.Info(), nil
+	case journal.KindFood:
+		m, err := nutrition.ParseMeal(e.Record)
+		if err != nil {
+			return "", err
+		}
+		return m.Info(), nil
+	default:
+		c := spentcalories.NewCalculator(a.cfg).WithLogger(a.logger)
+		info, err := c.TrainingInfo(e.Record, p.Weight, p.Height)
+		if err != nil {
+			return "", err
+		}
+		// Темп рассчитывается по беговым отрезкам, тренировки без бега выводятся без него.
+		if pace, err := c.PaceInfo(e.Record, p.Weight, p.Height, nil); err == nil {
+			info += pace
+		}
+		return info, nil
+	}
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+	"unicode"
+
+	"github.com/Yandex-Practicum/tracker/internal/body"
+	"github.com/Yandex-Practicum/tracker/internal/config"
+	"github.com/Yandex-Practicum/tracker/internal/journal"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/zone"
+)
+
+// Файлы в каталоге данных.
+const (
+	journalFile = "journal.txt"
+	profileFile = "profile.yaml"
+	bodyFile    = "body.txt"
+	auditFile   = "audit.jsonl"
+)
+
+// Форматы времени в аргументах командной строки.
+const (
+	dateLayout     = "2006-01-02"
+	dateTimeLayout = "2006-01-02 15:04"
+)
+
+// usersDir — каталог с данными пользователей внутри каталога установки.
+const usersDir = "users"
+
+// app — общее окружение подкоманд.
+type app struct {
+	// root — каталог установки, dir — каталог данных выбранного пользователя.
+	// Без пользователя они совпадают.
+	root, dir string
+	// user — выбранный пользователь; пусто — данные в самом каталоге установки.
+	user   string
+	cfg    config.Config
+	logger *slog.Logger
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// flagSet возвращает набор флагов подкоманды name со справкой usage.
+func (a *app) flagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Использование: tracker %s %s\n", name, usage)
+		hasFlags := false
+		fs.VisitAll(func(*flag.Flag) { hasFlags = true })
+		if hasFlags {
+			fmt.Fprintln(fs.Output(), "\nФлаги:")
+			fs.PrintDefaults()
+		}
+	}
+	return fs
+}
+
+// parseFlags разбирает флаги подкоманды; ошибки разбора считаются ошибками использования.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	return nil
+}
+
+// usageError возвращает ошибку использования с пояснением.
+func usageError(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", errUsage, fmt.Sprintf(format, args...))
+}
+
+// withUser возвращает копию окружения для пользователя name; пустое имя
+// означает данные в самом каталоге установки.
+func (a *app) withUser(name string) (*app, error) {
+	if err := validateUser(name); err != nil {
+		return nil, err
+	}
+	u := *a
+	u.dir = a.root
+	u.user = name
+	if name != "" {
+		u.dir = filepath.Join(a.root, usersDir, name)
+		if u.logger != nil {
+			u.logger = u.logger.With(slog.String("user", name))
+		}
+	}
+	return &u, nil
+}
+
+// validateUser проверяет, что имя пользователя можно использовать как имя каталога:
+// буквы, цифры, ".", "-" и "_", первым символом — буква или цифра.
+func validateUser(name string) error {
+	for i, r := range name {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+		case i > 0 && (r == '.' || r == '-' || r == '_'):
+		default:
+			return usageError("недопустимое имя пользователя %q: разрешены буквы, цифры, \".\", \"-\" и \"_\"", name)
+		}
+	}
+	return nil
+}
+
+func (a *app) journalPath() string {
+	return filepath.Join(a.dir, journalFile)
+}
+
+func (a *app) profilePath() string {
+	return filepath.Join(a.dir, profileFile)
+}
+
+func (a *app) bodyPath() string {
+	return filepath.Join(a.dir, bodyFile)
+}
+
+func (a *app) auditPath() string {
+	return filepath.Join(a.dir, auditFile)
+}
+
+// ensureDir создаёт каталог данных, если его нет.
+func (a *app) ensureDir() error {
+	if err := os.MkdirAll(a.dir, 0o755); err != nil {
+		return fmt.Errorf("не удалось создать каталог данных: %w", err)
+	}
+	return nil
+}
+
+// loadProfile читает профиль пользователя и подсказывает, как его задать.
+func (a *app) loadProfile() (profile.Profile, error) {
+	p, err := profile.Load(a.profilePath())
+	if errors.Is(err, profile.ErrNotFound) {
+		return profile.Profile{}, fmt.Errorf("%w: задайте его командой tracker profile -weight <кг> -height <м>", err)
+	}
+	return p, err
+}
+
+// loadBody читает историю измерений, относя их дни к часовому поясу пользователя.
+func (a *app) loadBody() (body.History, error) {
+	return body.Load(a.bodyPath(), a.location())
+}
+
+// location возвращает часовой пояс пользователя из профиля, а без профиля — местный.
+// По нему командная строка разбирает даты и делит записи на дни.
+func (a *app) location() *time.Location {
+	p, err := profile.Load(a.profilePath())
+	if err != nil {
+		return time.Local
+	}
+	return p.Location()
+}
+
+// parseTime разбирает момент времени в формате RFC 3339, "2006-01-02 15:04"
+// или "2006-01-02" в часовом поясе loc. Пустая строка означает текущий момент.
+func parseTime(s string, loc *time.Location) (time.Time, error) {
+	if s == "" {
+		return time.Now(), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation(dateTimeLayout, s, loc); err == nil {
+		return t, nil
+	}
+	if t, err := parseDate(s, loc); err == nil {
+		return t, nil
+	}
+	return time.Time{}, usageError("некорректное время %q: ожидается RFC 3339, %q или %q", s, dateTimeLayout, dateLayout)
+}
+
+// parseDate разбирает дату в формате "2006-01-02" и возвращает начало этого дня в поясе loc.
+func parseDate(s string, loc *time.Location) (time.Time, error) {
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return time.Time{}, usageError("некорректная дата %q: ожидается %q", s, dateLayout)
+	}
+	y, m, d := t.Date()
+	return zone.Date(y, m, d, loc), nil
+}
+
+// parseRange разбирает границы периода в формате "2006-01-02" в поясе loc.
+// Конец включается в период. По умолчанию период — последние семь дней,
+// включая сегодняшний.
+func parseRange(fromStr, toStr string, loc *time.Location) (time.Time, time.Time, error) {
+	y, m, d := time.Now().In(loc).Date()
+	if toStr != "" {
+		t, err := parseDate(toStr, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		y, m, d = t.Date()
+	}
+	to := zone.Date(y, m, d+1, loc)
+
+	from := zone.Date(y, m, d-6, loc)
+	if fromStr != "" {
+		f, err := parseDate(fromStr, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		from = f
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, usageError("начало периода %s позже конца", from.Format(dateLayout))
+	}
+	return from, to, nil
+}
+
+// historyRange возвращает полуинтервал из целых дней пояса loc, содержащий все записи.
+// Для пустого журнала возвращает нулевые границы.
+func historyRange(entries []journal.Entry, loc *time.Location) (time.Time, time.Time) {
+	if len(entries) == 0 {
+		return time.Time{}, time.Time{}
+	}
+	// Add дописывает записи в конец, поэтому журнал может быть не отсортирован.
+	first, last := entries[0].Time, entries[0].Time
+	for _, e := range entries[1:] {
+		first, last = minTime(first, e.Time), maxTime(last, e.Time)
+	}
+	y, m, d := last.In(loc).Date()
+	return zone.StartOfDay(first, loc), zone.Date(y, m, d+1, loc)
+}
+
+// historyUntilToday возвращает полуинтервал из целых дней пояса loc от первой
+// записи журнала до сегодняшнего дня включительно.
+func historyUntilToday(entries []journal.Entry, loc *time.Location) (time.Time, time.Time) {
+	y, m, d := time.Now().In(loc).Date()
+	today, tomorrow := zone.Date(y, m, d, loc), zone.Date(y, m, d+1, loc)
+
+	from, to := historyRange(entries, loc)
+	if len(entries) == 0 {
+		from = today
+	}
+	return from, maxTime(to, tomorrow)
+}
+
+// minTime возвращает более ранний из моментов a и b.
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+// maxTime возвращает более поздний из моментов a и b.
+func maxTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
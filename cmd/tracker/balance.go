@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/balance"
+	"github.com/Yandex-Practicum/tracker/internal/journal"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/Yandex-Practicum/tracker/internal/zone"
+)
+
+const balanceUsage = `[-weeks 4]
+
+Печатает энергетический баланс по дням за последние недели, включая текущую:
+калорийность приёмов пищи (записи food) минус основной обмен и расход на шаги
+и тренировки. Ниже — средние за день по неделям с линией тренда и оценка
+изменения веса. Основной обмен рассчитывается по формуле Миффлина — Сан Жеора,
+для неё в профиле нужны пол и год рождения: tracker profile -sex female -birth-year 1990.
+Дни без записей о питании в средние и итог не входят.`
+
+func runBalance(a *app, args []string) error {
+	fs := a.flagSet("balance", balanceUsage)
+	weeks := fs.Int("weeks", 4, "число недель, включая текущую")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageError("лишние аргументы: %v", fs.Args())
+	}
+	if *weeks <= 0 {
+		return usageError("число недель должно быть больше нуля: %d", *weeks)
+	}
+
+	p, err := a.loadProfile()
+	if err != nil {
+		return err
+	}
+	entries, err := journal.Load(a.journalPath())
+	if err != nil {
+		return err
+	}
+	h, err := a.loadBody()
+	if err != nil {
+		return err
+	}
+
+	today := zone.StartOfDay(time.Now(), p.Location())
+	from := shiftDays(zone.WeekStart(today), -7*(*weeks-1))
+	r := report.NewBuilder(a.cfg, a.logger).WithBody(h).Build(entries, p, from, shiftDays(today, 1))
+
+	res, err := balance.Compute(r, p, h)
+	if errors.Is(err, balance.ErrNoBMR) {
+		return fmt.Errorf("%w: задайте их командой tracker profile -sex male|female -birth-year <год>", err)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(a.stdout, res.Text())
+	return nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/body"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/zone"
+)
+
+const bodyUsage = `[-date 2006-01-02] [-weight кг] [-height м]
+
+Без флагов печатает историю измерений веса и роста. С флагами -weight или
+-height записывает измерение за день -date (по умолчанию сегодня). Записи
+журнала рассчитываются с весом и ростом, действовавшими в их день; самое
+позднее измерение переносится и в профиль.`
+
+func runBody(a *app, args []string) error {
+	fs := a.flagSet("body", bodyUsage)
+	dateStr := fs.String("date", "", "день измерения, по умолчанию сегодня")
+	weight := fs.Float64("weight", 0, "вес, кг")
+	height := fs.Float64("height", 0, "рост, м")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageError("лишние аргументы: %v", fs.Args())
+	}
+
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	h, err := a.loadBody()
+	if err != nil {
+		return err
+	}
+	if !set["weight"] && !set["height"] {
+		if set["date"] {
+			return usageError("укажите -weight или -height")
+		}
+		fmt.Fprint(a.stdout, h.Text())
+		return nil
+	}
+
+	loc := a.location()
+	date := zone.StartOfDay(time.Now(), loc)
+	if *dateStr != "" {
+		if date, err = parseDate(*dateStr, loc); err != nil {
+			return err
+		}
+	}
+	m := body.Measurement{Date: date, Weight: *weight, Height: *height}
+	if (set["weight"] && m.Weight <= 0) || (set["height"] && m.Height <= 0) {
+		return usageError("вес и рост должны быть больше нуля")
+	}
+
+	if err := a.ensureDir(); err != nil {
+		return err
+	}
+	latest := h.Latest(date)
+	if h, err = h.Add(m); err != nil {
+		return err
+	}
+	if err := body.Save(a.bodyPath(), h); err != nil {
+		return err
+	}
+
+	if latest {
+		p, err := profile.Load(a.profilePath())
+		switch {
+		case errors.Is(err, profile.ErrNotFound):
+		case err != nil:
+			return err
+		default:
+			if err := profile.Save(a.profilePath(), h.At(date, p)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, saved := range h {
+		if saved.Date.Equal(date) {
+			fmt.Fprintf(a.stdout, "Измерение сохранено: %s", body.History{saved}.Text())
+		}
+	}
+	return nil
+}
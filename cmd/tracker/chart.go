@@ -0,0 +1,44 @@
+package main
+
+import (
+	"io"
+
+	"github.com/Yandex-Practicum/tracker/internal/chart"
+)
+
+const chartUsage = `[-kind steps|calories|pace] [-format svg|png] [-o файл] [-from 2006-01-02] [-to 2006-01-02]
+
+Рисует диаграмму за период: шаги по дням, калории по типам тренировок
+или темп беговых тренировок. По умолчанию период — последние семь дней.`
+
+func runChart(a *app, args []string) error {
+	fs := a.flagSet("chart", chartUsage)
+	kind := fs.String("kind", string(chart.KindSteps), "вид диаграммы")
+	format := fs.String("format", string(chart.FormatSVG), "формат изображения")
+	out := fs.String("o", "-", "файл для записи, \"-\" — стандартный вывод")
+	fromStr := fs.String("from", "", "первый день периода")
+	toStr := fs.String("to", "", "последний день периода, по умолчанию сегодня")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageError("лишние аргументы: %v", fs.Args())
+	}
+	f := chart.Format(*format)
+	if f != chart.FormatSVG && f != chart.FormatPNG {
+		return usageError("неизвестный формат изображения: %q", *format)
+	}
+
+	r, err := a.buildReport(*fromStr, *toStr, "")
+	if err != nil {
+		return err
+	}
+	series, err := chart.FromReport(r, chart.Kind(*kind))
+	if err != nil {
+		return usageError("%v", err)
+	}
+
+	return writeOutput(a, *out, func(w io.Writer) error {
+		return chart.Render(w, series, f)
+	})
+}
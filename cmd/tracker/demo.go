@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/Yandex-Practicum/tracker/internal/daysteps"
+	"github.com/Yandex-Practicum/tracker/internal/parseerr"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+)
+
+const demoUsage = `
+
+Печатает расчёты для встроенного набора пакетов и тренировок
+с весом 84.6 кг и ростом 1.87 м. Журнал и профиль не используются.`
+
+func runDemo(a *app, args []string) error {
+	fs := a.flagSet("demo", demoUsage)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	logger := a.logger
+	steps := daysteps.NewCalculator(a.cfg).WithLogger(logger)
+	calories := spentcalories.NewCalculator(a.cfg).WithLogger(logger)
+
+	weight := 84.6
+	height := 1.87
+
+	// дневная активность
+	input := []string{
+		"678,0h50m",
+		"792,1h14m",
+		"1078,1h30m",
+		"7830,2h40m",
+		",3456",
+		"12:40:00, 3456",
+		"something is wrong",
+	}
+
+	fmt.Fprintln(a.stdout, "Активность в течение дня")
+
+	var (
+		dayActionsInfo string
+		dayActionsLog  []string
+	)
+
+	for i, v := range input {
+		lineLogger := logger.With(slog.String("source", "daysteps"), slog.Int("line", i+1))
+		dayActionsInfo = steps.WithLogger(lineLogger).DayActionInfo(v, weight, height)
+		dayActionsLog = append(dayActionsLog, dayActionsInfo)
+	}
+
+	for _, v := range dayActionsLog {
+		fmt.Fprintln(a.stdout, v)
+	}
+
+	// тренировки
+	trainings := []string{
+		"3456,Ходьба,3h00m",
+		"something is wrong",
+		"678,Бег,0h5m",
+		"1078,Бег,0h10m",
+		",3456 Ходьба",
+		"7892,Ходьба,3h10m",
+		"7892,Ходьба,3h10m,gain=420,loss=410",
+		"15392,Бег,0h45m",
+		"1500,Бег,6m;500,Ходьба,4m;1500,Бег,6m;500,Ходьба,4m",
+	}
+
+	var trainingLog []string
+
+	for i, v := range trainings {
+		trainingInfo, err := calories.TrainingInfo(v, weight, height)
+		if err != nil {
+			logger.Error("не получилось получить информацию о тренировке",
+				slog.String("source", "trainings"), slog.Int("line", i+1), slog.String("record", v), parseerr.Attr(err))
+			continue
+		}
+		// для бега дополняем сводку темпом и прогнозами
+		if paceInfo, err := calories.PaceInfo(v, weight, height, nil); err == nil {
+			trainingInfo += paceInfo
+		}
+		trainingLog = append(trainingLog, trainingInfo)
+	}
+
+	fmt.Fprintln(a.stdout, "Журнал тренировок")
+
+	for _, v := range trainingLog {
+		fmt.Fprintln(a.stdout, v)
+	}
+
+	return nil
+}
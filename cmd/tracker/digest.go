@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/digest"
+	"github.com/Yandex-Practicum/tracker/internal/journal"
+	"github.com/Yandex-Practicum/tracker/internal/zone"
+)
+
+const digestUsage = `[-week 2006-01-02] [-format markdown|text] [-o файл]
+
+Печатает сводку за неделю с понедельника по воскресенье, содержащую день -week
+(по умолчанию — текущую), в сравнении с предыдущей неделей.`
+
+func runDigest(a *app, args []string) error {
+	fs := a.flagSet("digest", digestUsage)
+	weekStr := fs.String("week", "", "любой день недели, по умолчанию сегодня")
+	format := fs.String("format", "markdown", "разметка: markdown или text")
+	out := fs.String("o", "-", "файл для записи, \"-\" — стандартный вывод")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageError("лишние аргументы: %v", fs.Args())
+	}
+
+	var render func(digest.Digest) string
+	switch *format {
+	case "markdown":
+		render = digest.Digest.Markdown
+	case "text":
+		render = digest.Digest.Text
+	default:
+		return usageError("неизвестная разметка: %q", *format)
+	}
+
+	p, err := a.loadProfile()
+	if err != nil {
+		return err
+	}
+
+	loc := p.Location()
+	day := time.Now().In(loc)
+	if *weekStr != "" {
+		if day, err = parseDate(*weekStr, loc); err != nil {
+			return err
+		}
+	}
+	from := zone.WeekStart(day)
+	entries, err := journal.Load(a.journalPath())
+	if err != nil {
+		return err
+	}
+	b, err := a.reportBuilder()
+	if err != nil {
+		return err
+	}
+	d := digest.New(
+		b.Build(entries, p, from, shiftDays(from, 7)),
+		b.Build(entries, p, shiftDays(from, -7), from),
+	)
+
+	return writeOutput(a, *out, func(w io.Writer) error {
+		_, err := fmt.Fprint(w, render(d))
+		return err
+	})
+}
+
+// shiftDays возвращает начало дня, отстоящего от дня t на n календарных дней.
+func shiftDays(t time.Time, n int) time.Time {
+	y, m, d := t.Date()
+	return zone.Date(y, m, d+n, t.Location())
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/audit"
+	"github.com/Yandex-Practicum/tracker/internal/journal"
+)
+
+const editUsage = `-at время [-kind steps|training|sleep|rest|food] [-by имя] <исправленная запись>
+
+Заменяет данные записи журнала, начатой в момент -at, и печатает её новую
+сводку. Исправленная запись проверяется до сохранения, а исходная остаётся
+в истории изменений (команда audit) вместе с автором и временем правки.
+Если в этот момент начато несколько записей, уточните вид флагом -kind.`
+
+const deleteUsage = `-at время [-kind steps|training|sleep|rest|food] [-by имя]
+
+Удаляет запись журнала, начатую в момент -at. Удалённая запись остаётся
+в истории изменений и восстанавливается командой undo.`
+
+const undoUsage = `[-by имя]
+
+Отменяет последнее ещё не отменённое исправление или удаление.
+Отмена тоже записывается в историю изменений.`
+
+const auditUsage = `[-format text|jsonl] [-o файл]
+
+Выгружает историю исправлений, удалений и отмен: номер, время, автора,
+исходную и новую запись.`
+
+// changeFlags добавляет флаг автора изменения.
+func changeFlags(fs *flag.FlagSet) *string {
+	return fs.String("by", os.Getenv("USER"), "автор изменения для истории")
+}
+
+// entryFlags добавляет флаги, выбирающие запись журнала.
+func entryFlags(fs *flag.FlagSet) (at, kind *string) {
+	at = fs.String("at", "", "время начала записи: RFC 3339, \"2006-01-02 15:04\" или \"2006-01-02\"")
+	kind = fs.String("kind", "", "вид записи, если в это время начато несколько")
+	return at, kind
+}
+
+func runEdit(a *app, args []string) error {
+	fs := a.flagSet("edit", editUsage)
+	at, kind := entryFlags(fs)
+	by := changeFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageError("ожидается исправленная запись, получено аргументов: %d", fs.NArg())
+	}
+
+	entries, old, err := a.findEntry(*at, *kind)
+	if err != nil {
+		return err
+	}
+	updated := journal.Entry{Time: old.Time, Kind: old.Kind, Record: fs.Arg(0)}
+	if updated.Record == old.Record {
+		return usageError("запись не изменилась: %q", old.Record)
+	}
+	info, err := a.describe(updated)
+	if err != nil {
+		return err
+	}
+
+	c := audit.Change{Author: *by, Action: audit.ActionEdit, Before: old, After: updated}
+	if err := a.applyChange(entries, c); err != nil {
+		return err
+	}
+	fmt.Fprint(a.stdout, info)
+	return nil
+}
+
+func runDelete(a *app, args []string) error {
+	fs := a.flagSet("delete", deleteUsage)
+	at, kind := entryFlags(fs)
+	by := changeFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageError("лишние аргументы: %v", fs.Args())
+	}
+
+	entries, old, err := a.findEntry(*at, *kind)
+	if err != nil {
+		return err
+	}
+	c := audit.Change{Author: *by, Action: audit.ActionDelete, Before: old}
+	if err := a.applyChange(entries, c); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "Удалена запись %s %s %s\n", old.Time.Format(time.RFC3339), old.Kind, old.Record)
+	return nil
+}
+
+func runUndo(a *app, args []string) error {
+	fs := a.flagSet("undo", undoUsage)
+	by := changeFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageError("лишние аргументы: %v", fs.Args())
+	}
+
+	changes, err := audit.Load(a.auditPath())
+	if err != nil {
+		return err
+	}
+	last, ok := audit.LastUndoable(changes)
+	if !ok {
+		return fmt.Errorf("нечего отменять")
+	}
+	entries, err := journal.Load(a.journalPath())
+	if err != nil {
+		return err
+	}
+
+	c := last.Revert(*by)
+	if err := a.applyChange(entries, c); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "Отменено изменение #%d (%s)\n", last.ID, last.Action)
+	return nil
+}
+
+func runAudit(a *app, args []string) error {
+	fs := a.flagSet("audit", auditUsage)
+	format := fs.String("format", "text", "формат: text или jsonl")
+	out := fs.String("o", "-", "файл для записи, \"-\" — стандартный вывод")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageError("лишние аргументы: %v", fs.Args())
+	}
+
+	var write func(io.Writer, []audit.Change) error
+	switch *format {
+	case "text":
+		write = audit.WriteText
+	case "jsonl":
+		write = audit.WriteJSONL
+	default:
+		return usageError("неизвестный формат: %q", *format)
+	}
+
+	changes, err := audit.Load(a.auditPath())
+	if err != nil {
+		return err
+	}
+	return writeOutput(a, *out, func(w io.Writer) error {
+		return write(w, changes)
+	})
+}
+
+// findEntry читает журнал и возвращает его вместе с единственной записью,
+// начатой в момент atStr, с видом kindStr, если он указан.
+func (a *app) findEntry(atStr, kindStr string) ([]journal.Entry, journal.Entry, error) {
+	if atStr == "" {
+		return nil, journal.Entry{}, usageError("укажите время начала записи флагом -at")
+	}
+	at, err := parseTime(atStr, a.location())
+	if err != nil {
+		return nil, journal.Entry{}, err
+	}
+	var kind journal.Kind
+	if kindStr != "" {
+		if kind, err = journal.ParseKind(kindStr); err != nil {
+			return nil, journal.Entry{}, usageError("%v", err)
+		}
+	}
+
+	entries, err := journal.Load(a.journalPath())
+	if err != nil {
+		return nil, journal.Entry{}, err
+	}
+	var found []journal.Entry
+	for _, e := range entries {
+		if e.Time.Equal(at) && (kind == "" || e.Kind == kind) {
+			found = append(found, e)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, journal.Entry{}, fmt.Errorf("в журнале нет записи, начатой %s", at.Format(time.RFC3339))
+	case 1:
+		return entries, found[0], nil
+	default:
+		return nil, journal.Entry{}, usageError("в %s начато записей: %d; уточните вид флагом -kind", at.Format(time.RFC3339), len(found))
+	}
+}
+
+// applyChange применяет изменение c к журналу entries, сохраняет журнал
+// и дописывает изменение с очередным номером и текущим временем в историю.
+func (a *app) applyChange(entries []journal.Entry, c audit.Change) error {
+	changes, err := audit.Load(a.auditPath())
+	if err != nil {
+		return err
+	}
+	c.ID = audit.NextID(changes)
+	c.Time = time.Now()
+
+	updated, err := c.Apply(entries)
+	if err != nil {
+		return err
+	}
+	if err := journal.Save(a.journalPath(), updated); err != nil {
+		return err
+	}
+	return audit.Append(a.auditPath(), c)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Yandex-Practicum/tracker/internal/journal"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/Yandex-Practicum/tracker/internal/trainingload"
+)
+
+const loadUsage = `[-days 14] [-run-speed км/ч] [-walk-speed км/ч] [-bike-speed км/ч] [-swim-speed км/ч] [-threshold-hr уд/мин] [-resting-hr уд/мин]
+
+Печатает за последние дни нагрузку тренировок, хроническую (CTL) и острую (ATL)
+нагрузку и баланс (TSB). Нагрузка часа на пороге — 100 единиц; интенсивность
+оценивается по пульсу hr, если он записан, иначе по средней скорости.
+Модель рассчитывается по всей истории журнала.`
+
+func runLoad(a *app, args []string) error {
+	th := trainingload.DefaultThresholds()
+	fs := a.flagSet("load", loadUsage)
+	days := fs.Int("days", 14, "число последних дней в таблице")
+	runSpeed := fs.Float64("run-speed", th.Speed[spentcalories.RunningType], "пороговая скорость бега, км/ч")
+	walkSpeed := fs.Float64("walk-speed", th.Speed[spentcalories.WalkingType], "пороговая скорость ходьбы, км/ч")
+	bikeSpeed := fs.Float64("bike-speed", th.Speed[spentcalories.CyclingType], "пороговая скорость езды на велосипеде, км/ч")
+	swimSpeed := fs.Float64("swim-speed", th.Speed[spentcalories.SwimmingType], "пороговая скорость плавания, км/ч")
+	fs.Float64Var(&th.HeartRate, "threshold-hr", th.HeartRate, "пороговый пульс, уд/мин")
+	fs.Float64Var(&th.RestingHeartRate, "resting-hr", th.RestingHeartRate, "пульс в покое, уд/мин")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageError("лишние аргументы: %v", fs.Args())
+	}
+	if *days <= 0 {
+		return usageError("число дней должно быть больше нуля: %d", *days)
+	}
+	th.Speed[spentcalories.RunningType] = *runSpeed
+	th.Speed[spentcalories.WalkingType] = *walkSpeed
+	th.Speed[spentcalories.CyclingType] = *bikeSpeed
+	th.Speed[spentcalories.SwimmingType] = *swimSpeed
+
+	p, err := a.loadProfile()
+	if err != nil {
+		return err
+	}
+	entries, err := journal.Load(a.journalPath())
+	if err != nil {
+		return err
+	}
+	b, err := a.reportBuilder()
+	if err != nil {
+		return err
+	}
+	from, to := historyUntilToday(entries, p.Location())
+
+	model := trainingload.Model(b.Build(entries, p, from, to), th)
+	fmt.Fprint(a.stdout, trainingload.Text(model[max(0, len(model)-*days):]))
+	return nil
+}
@@ -1,69 +1,189 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
 
-	"github.com/Yandex-Practicum/tracker/internal/daysteps"
-	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/Yandex-Practicum/tracker/internal/config"
+	"github.com/Yandex-Practicum/tracker/internal/parseerr"
 )
 
-func main() {
-	weight := 84.6
-	height := 1.87
+// Коды завершения.
+const (
+	exitOK    = 0 // команда выполнена.
+	exitError = 1 // ошибка выполнения: данные, файлы, сеть.
+	exitUsage = 2 // неверные аргументы командной строки.
+)
 
-	// дневная активность
-	input := []string{
-		"678,0h50m",
-		"792,1h14m",
-		"1078,1h30m",
-		"7830,2h40m",
-		",3456",
-		"12:40:00, 3456",
-		"something is wrong",
-	}
+// errUsage оборачивает ошибки аргументов командной строки.
+var errUsage = errors.New("неверные аргументы")
 
-	fmt.Println("Активность в течение дня")
+// command — подкоманда трекера.
+type command struct {
+	summary string
+	// usage — строка с аргументами для справки.
+	usage string
+	run   func(a *app, args []string) error
+}
 
-	var (
-		dayActionsInfo string
-		dayActionsLog  []string
-	)
+// commands — подкоманды по имени.
+var commands = map[string]command{
+	"add":          {summary: "добавить шаги, тренировку, сон, отдых или приём пищи", usage: addUsage, run: runAdd},
+	"achievements": {summary: "серии, рекорды и значки", usage: achievementsUsage, run: runAchievements},
+	"audit":        {summary: "история исправлений и удалений", usage: auditUsage, run: runAudit},
+	"balance":      {summary: "энергетический баланс: питание, обмен и активность", usage: balanceUsage, run: runBalance},
+	"body":         {summary: "история веса и роста", usage: bodyUsage, run: runBody},
+	"chart":        {summary: "нарисовать диаграмму SVG или PNG", usage: chartUsage, run: runChart},
+	"delete":       {summary: "удалить запись журнала", usage: deleteUsage, run: runDelete},
+	"digest":       {summary: "недельный дайджест в Markdown", usage: digestUsage, run: runDigest},
+	"edit":         {summary: "исправить запись журнала", usage: editUsage, run: runEdit},
+	"load":         {summary: "нагрузка, форма и усталость (CTL/ATL/TSB)", usage: loadUsage, run: runLoad},
+	"report":       {summary: "сводка за период", usage: reportUsage, run: runReport},
+	"import":       {summary: "загрузить записи из файла", usage: importUsage, run: runImport},
+	"export":       {summary: "выгрузить записи в файл", usage: exportUsage, run: runExport},
+	"profile":      {summary: "показать или изменить профиль: вес, рост, цель, пояс", usage: profileUsage, run: runProfile},
+	"serve":        {summary: "отдавать отчёты по HTTP", usage: serveUsage, run: runServe},
+	"undo":         {summary: "отменить последнее исправление или удаление", usage: undoUsage, run: runUndo},
+	"users":        {summary: "список пользователей", usage: usersUsage, run: runUsers},
+	"tui":          {summary: "полноэкранная сводка за сегодня", usage: tuiUsage, run: runTUI},
+	"demo":         {summary: "расчёт на встроенном примере", usage: demoUsage, run: runDemo},
+}
 
-	for _, v := range input {
-		dayActionsInfo = daysteps.DayActionInfo(v, weight, height)
-		dayActionsLog = append(dayActionsLog, dayActionsInfo)
-	}
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
 
-	for _, v := range dayActionsLog {
-		fmt.Println(v)
+// run разбирает общие флаги, выполняет подкоманду и возвращает код завершения.
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("tracker", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	dir := fs.String("dir", defaultDir(), "каталог с журналом и профилем")
+	user := fs.String("user", os.Getenv("TRACKER_USER"), "пользователь: его данные хранятся в каталоге users/<имя> внутри -dir")
+	configPath := fs.String("config", "", "файл YAML или JSON с коэффициентами расчётов")
+	logFormat := fs.String("log-format", "text", "формат журнала: text или json")
+	fs.Usage = func() { usage(fs) }
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
 	}
 
-	// тренировки
-	trainings := []string{
-		"3456,Ходьба,3h00m",
-		"something is wrong",
-		"678,Бег,0h5m",
-		"1078,Бег,0h10m",
-		",3456 Ходьба",
-		"7892,Ходьба,3h10m",
-		"15392,Бег,0h45m",
+	logger, err := newLogger(stderr, *logFormat)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
 	}
+	slog.SetDefault(logger)
 
-	var trainingLog []string
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	name, rest := fs.Arg(0), fs.Args()[1:]
+	if name == "help" {
+		return help(fs, rest, stdout, stderr, logger)
+	}
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(stderr, "неизвестная команда: %q\n", name)
+		fs.Usage()
+		return exitUsage
+	}
 
-	for _, v := range trainings {
-		trainingInfo, err := spentcalories.TrainingInfo(v, weight, height)
+	cfg := config.Default()
+	if *configPath != "" {
+		cfg, err = config.Load(*configPath)
 		if err != nil {
-			log.Printf("не получилось получить информацию о тренировке: %v", err)
-			continue
+			logger.Error("не удалось загрузить конфигурацию", slog.String("path", *configPath), parseerr.Attr(err))
+			return exitError
+		}
+	}
+
+	a := &app{root: *dir, cfg: cfg, logger: logger, stdout: stdout, stderr: stderr}
+	if a, err = a.withUser(*user); err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+	if err := cmd.run(a, rest); err != nil {
+		switch {
+		case errors.Is(err, flag.ErrHelp):
+			return exitOK
+		case errors.Is(err, errUsage):
+			fmt.Fprintln(stderr, err)
+			return exitUsage
+		default:
+			logger.Error("команда завершилась с ошибкой", slog.String("command", name), parseerr.Attr(err))
+			return exitError
 		}
-		trainingLog = append(trainingLog, trainingInfo)
 	}
+	return exitOK
+}
+
+// usage печатает общую справку.
+func usage(fs *flag.FlagSet) {
+	w := fs.Output()
+	fmt.Fprintln(w, "Использование: tracker [флаги] <команда> [аргументы]")
+	fmt.Fprintln(w, "\nКоманды:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-13s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintln(w, "\nСправка по команде: tracker help <команда>")
+	fmt.Fprintln(w, "\nФлаги:")
+	fs.PrintDefaults()
+}
 
-	fmt.Println("Журнал тренировок")
+// help печатает общую справку или справку по команде с её флагами.
+func help(fs *flag.FlagSet, args []string, stdout, stderr io.Writer, logger *slog.Logger) int {
+	if len(args) == 0 {
+		fs.SetOutput(stdout)
+		fs.Usage()
+		return exitOK
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "неизвестная команда: %q\n", args[0])
+		return exitUsage
+	}
+	a := &app{logger: logger, stdout: stdout, stderr: stdout}
+	if err := cmd.run(a, []string{"-h"}); !errors.Is(err, flag.ErrHelp) {
+		return exitError
+	}
+	return exitOK
+}
+
+// defaultDir возвращает каталог данных по умолчанию: $TRACKER_DIR или tracker в каталоге настроек пользователя.
+func defaultDir() string {
+	if dir := os.Getenv("TRACKER_DIR"); dir != "" {
+		return dir
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "tracker"
+	}
+	return filepath.Join(dir, "tracker")
+}
 
-	for _, v := range trainingLog {
-		fmt.Println(v)
+// newLogger возвращает журнал в w в формате text или json.
+func newLogger(w io.Writer, format string) (*slog.Logger, error) {
+	switch format {
+	case "text":
+		return slog.New(slog.NewTextHandler(w, nil)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, nil)), nil
+	default:
+		return nil, fmt.Errorf("неизвестный формат журнала: %q", format)
 	}
 }
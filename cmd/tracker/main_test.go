@@ -0,0 +1,514 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type TrackerTestSuite struct {
+	suite.Suite
+	dir string
+}
+
+func TestTrackerSuite(t *testing.T) {
+	suite.Run(t, new(TrackerTestSuite))
+}
+
+func (suite *TrackerTestSuite) SetupTest() {
+	suite.dir = suite.T().TempDir()
+}
+
+// run выполняет команду трекера в каталоге теста.
+func (suite *TrackerTestSuite) run(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(append([]string{"-dir", suite.dir}, args...), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func (suite *TrackerTestSuite) TestUsage() {
+	code, _, stderr := suite.run()
+	assert.Equal(suite.T(), exitUsage, code)
+	assert.Contains(suite.T(), stderr, "Команды:")
+
+	code, _, _ = suite.run("unknown")
+	assert.Equal(suite.T(), exitUsage, code)
+
+	code, stdout, _ := suite.run("help", "report")
+	assert.Equal(suite.T(), exitOK, code)
+	assert.Contains(suite.T(), stdout, "tracker report")
+	assert.Contains(suite.T(), stdout, "-from")
+
+	code, _, _ = suite.run("add", "steps")
+	assert.Equal(suite.T(), exitUsage, code)
+}
+
+func (suite *TrackerTestSuite) TestAddAndReport() {
+	code, _, _ := suite.run("add", "steps", "6000,1h00m")
+	assert.Equal(suite.T(), exitError, code, "без профиля запись добавлять нельзя")
+
+	code, _, _ = suite.run("profile", "-weight", "75", "-height", "1.75")
+	suite.Require().Equal(exitOK, code)
+
+	code, stdout, _ := suite.run("add", "-at", "2026-10-12 08:00", "steps", "6000,1h00m")
+	suite.Require().Equal(exitOK, code)
+	assert.Equal(suite.T(), "Количество шагов: 6000.\nДистанция составила 3.90 км.\nВы сожгли 177.19 ккал.\n", stdout)
+
+	code, stdout, _ = suite.run("add", "-at", "2026-10-12 18:00", "training", "6000,Бег,1h00m")
+	suite.Require().Equal(exitOK, code)
+	assert.Contains(suite.T(), stdout, "Сожгли калорий: 354.38\nТемп: 12:42 мин/км\nСплит 1 (1.00 км): 12:42\n")
+	assert.Contains(suite.T(), stdout, "Прогноз на 5 км: ")
+
+	code, _, _ = suite.run("add", "-at", "2026-10-12 19:00", "training", "6000,Плавание,1h00m")
+	assert.Equal(suite.T(), exitError, code)
+
+	code, stdout, _ = suite.run("report", "-from", "2026-10-12", "-to", "2026-10-12")
+	suite.Require().Equal(exitOK, code)
+	assert.Contains(suite.T(), stdout, "12.10.2026: шагов 6000, 3.90 км, 177.19 ккал; тренировок 1: 4.72 км, 354.38 ккал; темп бега 12:42 мин/км\n")
+	assert.Contains(suite.T(), stdout, "Итого: шагов 6000, 3.90 км, 177.19 ккал; тренировок 1: 4.72 км, 354.38 ккал\n")
+
+	code, _, _ = suite.run("report", "-from", "12.10.2026")
+	assert.Equal(suite.T(), exitUsage, code)
+}
+
+func (suite *TrackerTestSuite) TestImportExport() {
+	code, _, _ := suite.run("profile", "-weight", "75", "-height", "1.75")
+	suite.Require().Equal(exitOK, code)
+
+	src := filepath.Join(suite.T().TempDir(), "in.csv")
+	suite.Require().NoError(os.WriteFile(src, []byte("time,kind,record\n"+
+		"2026-10-12T08:00:00Z,steps,\"6000,1h00m\"\n"+
+		"2026-10-12T18:00:00Z,training,\"6000,Бег,1h00m\"\n"), 0o600))
+
+	code, stdout, _ := suite.run("import", src)
+	suite.Require().Equal(exitOK, code)
+	assert.Equal(suite.T(), "Загружено записей: 2\n", stdout)
+
+	code, stdout, _ = suite.run("export", "-format", "jsonl")
+	suite.Require().Equal(exitOK, code)
+	assert.Equal(suite.T(), `{"time":"2026-10-12T08:00:00Z","kind":"steps","record":"6000,1h00m"}`+"\n"+
+		`{"time":"2026-10-12T18:00:00Z","kind":"training","record":"6000,Бег,1h00m"}`+"\n", stdout)
+
+	bad := filepath.Join(suite.T().TempDir(), "bad.csv")
+	suite.Require().NoError(os.WriteFile(bad, []byte("time,kind,record\n2026-10-13T08:00:00Z,steps,\"0,1h00m\"\n"), 0o600))
+	code, _, _ = suite.run("import", bad)
+	assert.Equal(suite.T(), exitError, code)
+
+	code, stdout, _ = suite.run("export", "-format", "text")
+	suite.Require().Equal(exitOK, code)
+	assert.NotContains(suite.T(), stdout, "2026-10-13", "журнал не должен меняться при ошибке импорта")
+}
+
+func (suite *TrackerTestSuite) TestSourceMetadata() {
+	code, _, _ := suite.run("profile", "-weight", "75", "-height", "1.75")
+	suite.Require().Equal(exitOK, code)
+
+	src := filepath.Join(suite.T().TempDir(), "in.csv")
+	suite.Require().NoError(os.WriteFile(src, []byte("time,kind,record\n"+
+		"2026-10-12T08:00:00Z,steps,\"6000,1h00m,source=watch,device=Forerunner 255\"\n"+
+		"2026-10-12T12:00:00Z,steps,\"3000,30m,source=phone\"\n"), 0o600))
+
+	code, _, _ = suite.run("import", "-batch", "b1", src)
+	suite.Require().Equal(exitOK, code)
+
+	code, stdout, _ := suite.run("export", "-format", "text", "-source", "watch")
+	suite.Require().Equal(exitOK, code)
+	assert.Equal(suite.T(), "2026-10-12T08:00:00Z\tsteps\t6000,1h00m,source=watch,device=Forerunner 255,batch=b1\n", stdout)
+
+	code, stdout, _ = suite.run("report", "-from", "2026-10-12", "-to", "2026-10-12", "-source", "phone")
+	suite.Require().Equal(exitOK, code)
+	assert.Contains(suite.T(), stdout, "Итого: шагов 3000,")
+
+	code, stdout, _ = suite.run("add", "-at", "2026-10-13 08:00", "steps", "600,10m,source=watch,firmware=20.26")
+	suite.Require().Equal(exitOK, code)
+	assert.Contains(suite.T(), stdout, "Данные: источник watch, прошивка 20.26\n")
+
+	code, _, _ = suite.run("import", "-batch", "b,2", src)
+	assert.Equal(suite.T(), exitUsage, code)
+}
+
+func (suite *TrackerTestSuite) TestExportHTML() {
+	code, _, _ := suite.run("profile", "-weight", "75", "-height", "1.75")
+	suite.Require().Equal(exitOK, code)
+	code, _, _ = suite.run("add", "-at", "2026-10-12 18:00", "training", "6000,Бег,1h00m")
+	suite.Require().Equal(exitOK, code)
+
+	out := filepath.Join(suite.T().TempDir(), "week.html")
+	code, _, _ = suite.run("export", "-format", "html", "-from", "2026-10-12", "-to", "2026-10-18", "-athlete", "Иван", "-o", out)
+	suite.Require().Equal(exitOK, code)
+	data, err := os.ReadFile(out)
+	suite.Require().NoError(err)
+	assert.Contains(suite.T(), string(data), "Иван: отчёт за 12.10.2026 — 18.10.2026")
+	assert.Contains(suite.T(), string(data), "<td>Бег</td>")
+}
+
+func (suite *TrackerTestSuite) TestExportICS() {
+	code, _, _ := suite.run("profile", "-weight", "75", "-height", "1.75")
+	suite.Require().Equal(exitOK, code)
+	code, _, _ = suite.run("add", "-at", "2026-10-12 18:00", "training", "6000,Ходьба,1h00m")
+	suite.Require().Equal(exitOK, code)
+	code, _, _ = suite.run("add", "-at", "2026-10-05 18:00", "training", "6000,Бег,1h00m")
+	suite.Require().Equal(exitOK, code)
+
+	code, stdout, _ := suite.run("export", "-format", "ics")
+	suite.Require().Equal(exitOK, code)
+	assert.Equal(suite.T(), 2, strings.Count(stdout, "BEGIN:VEVENT"))
+	assert.Contains(suite.T(), stdout, "SUMMARY:Бег\r\n")
+	assert.Contains(suite.T(), stdout, "SUMMARY:Ходьба\r\n")
+
+	code, stdout, _ = suite.run("export", "-format", "ics", "-from", "2026-10-12", "-to", "2026-10-12")
+	suite.Require().Equal(exitOK, code)
+	assert.Equal(suite.T(), 1, strings.Count(stdout, "BEGIN:VEVENT"))
+}
+
+func (suite *TrackerTestSuite) TestAchievements() {
+	code, _, _ := suite.run("profile", "-weight", "75", "-height", "1.75")
+	suite.Require().Equal(exitOK, code)
+
+	code, stdout, _ := suite.run("add", "-at", "2026-10-12 18:00", "training", "6000,Бег,0h40m")
+	suite.Require().Equal(exitOK, code)
+	assert.NotContains(suite.T(), stdout, "рекорд")
+
+	code, stdout, _ = suite.run("add", "-at", "2026-10-13 18:00", "training", "13000,Бег,1h10m")
+	suite.Require().Equal(exitOK, code)
+	assert.Contains(suite.T(), stdout, "Новый рекорд: самый длинный бег 10.24 км\n")
+	assert.Contains(suite.T(), stdout, "Новый значок: Первые 10 км\n")
+
+	code, stdout, _ = suite.run("achievements")
+	suite.Require().Equal(exitOK, code)
+	assert.Contains(suite.T(), stdout, "Самый длинный бег: 10.24 км (13.10.2026)\n")
+	assert.Contains(suite.T(), stdout, "Значок «Первые 10 км» (13.10.2026)\n")
+}
+
+func (suite *TrackerTestSuite) TestLoad() {
+	code, _, _ := suite.run("profile", "-weight", "75", "-height", "1.75")
+	suite.Require().Equal(exitOK, code)
+	yesterday := time.Now().AddDate(0, 0, -1).Format(dateLayout)
+	code, _, _ = suite.run("add", "-at", yesterday, "training", "6000,Бег,1h00m,hr=170")
+	suite.Require().Equal(exitOK, code)
+
+	code, stdout, _ := suite.run("load", "-days", "2")
+	suite.Require().Equal(exitOK, code)
+	lines := strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+	suite.Require().Len(lines, 3)
+	assert.Contains(suite.T(), lines[1], "     100    2.4   14.3    0.0")
+	assert.Contains(suite.T(), lines[2], "       0    2.3   12.2  -11.9")
+
+	code, _, _ = suite.run("load", "-days", "0")
+	assert.Equal(suite.T(), exitUsage, code)
+}
+
+func (suite *TrackerTestSuite) TestBody() {
+	code, _, _ := suite.run("profile", "-weight", "75", "-height", "1.75")
+	suite.Require().Equal(exitOK, code)
+	code, _, _ = suite.run("add", "-at", "2020-10-12 18:00", "training", "6000,Бег,1h00m")
+	suite.Require().Equal(exitOK, code)
+	code, _, _ = suite.run("add", "-at", "2020-10-13 18:00", "training", "6000,Бег,1h00m")
+	suite.Require().Equal(exitOK, code)
+
+	code, stdout, _ := suite.run("body", "-date", "2020-10-01", "-weight", "75")
+	suite.Require().Equal(exitOK, code)
+	assert.Equal(suite.T(), "Измерение сохранено: 01.10.2020: вес 75.0 кг\n", stdout)
+	code, _, _ = suite.run("body", "-date", "2020-10-13", "-weight", "60")
+	suite.Require().Equal(exitOK, code)
+
+	code, stdout, _ = suite.run("report", "-from", "2020-10-12", "-to", "2020-10-13")
+	suite.Require().Equal(exitOK, code)
+	assert.Contains(suite.T(), stdout, "12.10.2020: шагов 0, 0.00 км, 0.00 ккал; тренировок 1: 4.72 км, 354.38 ккал; темп бега 12:42 мин/км\n")
+	assert.Contains(suite.T(), stdout, "13.10.2020: шагов 0, 0.00 км, 0.00 ккал; тренировок 1: 4.72 км, 283.50 ккал; темп бега 12:42 мин/км\n")
+
+	code, stdout, _ = suite.run("body")
+	suite.Require().Equal(exitOK, code)
+	assert.Contains(suite.T(), stdout, "01.10.2020: вес 75.0 кг\n13.10.2020: вес 60.0 кг\n")
+
+	// Измерение задним числом не меняет профиль: сегодняшнее измерение позже.
+	code, stdout, _ = suite.run("profile")
+	suite.Require().Equal(exitOK, code)
+	assert.Contains(suite.T(), stdout, "Вес: 75.0 кг\n")
+}
+
+func (suite *TrackerTestSuite) TestUsers() {
+	code, _, _ := suite.run("-user", "anna", "profile", "-weight", "60", "-height", "1.65")
+	suite.Require().Equal(exitOK, code)
+	code, _, _ = suite.run("-user", "борис", "profile", "-weight", "90", "-height", "1.9")
+	suite.Require().Equal(exitOK, code)
+	code, _, _ = suite.run("-user", "anna", "add", "-at", "2026-10-12 18:00", "training", "6000,Бег,1h00m")
+	suite.Require().Equal(exitOK, code)
+
+	code, stdout, _ := suite.run("-user", "борис", "export", "-format", "text")
+	suite.Require().Equal(exitOK, code)
+	assert.Empty(suite.T(), stdout, "журналы пользователей не должны смешиваться")
+	code, stdout, _ = suite.run("-user", "anna", "export", "-format", "text")
+	suite.Require().Equal(exitOK, code)
+	assert.Contains(suite.T(), stdout, "6000,Бег,1h00m")
+	assert.FileExists(suite.T(), filepath.Join(suite.dir, "users", "anna", "journal.txt"))
+
+	code, _, _ = suite.run("profile")
+	assert.Equal(suite.T(), exitError, code, "без -user используется общий профиль")
+
+	code, stdout, _ = suite.run("users")
+	suite.Require().Equal(exitOK, code)
+	assert.Equal(suite.T(), "anna\nборис\n", stdout)
+
+	for _, name := range []string{"../anna", ".hidden", "a/b"} {
+		code, _, _ = suite.run("-user", name, "profile")
+		assert.Equal(suite.T(), exitUsage, code, name)
+	}
+
+	root := &app{root: suite.dir, cfg: config.Default(), logger: slog.Default()}
+	anna, err := root.withUser("anna")
+	suite.Require().NoError(err)
+	rec := httptest.NewRecorder()
+	anna.handler(false).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/export?format=text", nil))
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), "6000,Бег,1h00m", "сервер отдаёт данные пользователя из -user")
+
+	// По умолчанию выбрать другого пользователя параметром запроса нельзя.
+	boris, err := root.withUser("борис")
+	suite.Require().NoError(err)
+	for _, target := range []string{"/export?format=text&user=anna", "/report?user=anna"} {
+		rec = httptest.NewRecorder()
+		boris.handler(false).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(suite.T(), http.StatusForbidden, rec.Code, target)
+		assert.NotContains(suite.T(), rec.Body.String(), "6000,Бег,1h00m", target)
+	}
+
+	rec = httptest.NewRecorder()
+	anna.handler(false).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/export?format=text&user=anna", nil))
+	assert.Equal(suite.T(), http.StatusOK, rec.Code, "свой пользователь в параметре user разрешён")
+	assert.Contains(suite.T(), rec.Body.String(), "6000,Бег,1h00m")
+
+	rec = httptest.NewRecorder()
+	boris.handler(true).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/export?format=text&user=anna", nil))
+	assert.Equal(suite.T(), http.StatusOK, rec.Code, "с -any-user пользователя выбирает параметр user")
+	assert.Contains(suite.T(), rec.Body.String(), "6000,Бег,1h00m")
+
+	rec = httptest.NewRecorder()
+	boris.handler(true).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/export?user=..", nil))
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+}
+
+func (suite *TrackerTestSuite) TestDigest() {
+	code, _, _ := suite.run("profile", "-weight", "75", "-height", "1.75")
+	suite.Require().Equal(exitOK, code)
+	code, _, _ = suite.run("add", "-at", "2026-10-14 18:00", "training", "6000,Бег,1h00m")
+	suite.Require().Equal(exitOK, code)
+
+	code, stdout, _ := suite.run("digest", "-week", "2026-10-18")
+	suite.Require().Equal(exitOK, code)
+	assert.Contains(suite.T(), stdout, "## Неделя 12.10.2026 — 18.10.2026\n")
+	assert.Contains(suite.T(), stdout, "- Тренировок: 1 (неделей ранее 0)\n")
+
+	code, _, _ = suite.run("digest", "-format", "html")
+	assert.Equal(suite.T(), exitUsage, code)
+}
+
+func (suite *TrackerTestSuite) TestTimezone() {
+	code, _, _ := suite.run("profile", "-weight", "75", "-height", "1.75", "-tz", "Mars/Olympus")
+	assert.Equal(suite.T(), exitError, code)
+
+	code, _, _ = suite.run("profile", "-weight", "75", "-height", "1.75", "-tz", "Asia/Tokyo")
+	suite.Require().Equal(exitOK, code)
+	code, stdout, _ := suite.run("profile")
+	suite.Require().Equal(exitOK, code)
+	assert.Contains(suite.T(), stdout, "Часовой пояс: Asia/Tokyo\n")
+
+	// Время без пояса читается по Токио, а 15:30 UTC там уже 00:30 следующего дня.
+	code, _, _ = suite.run("add", "-at", "2026-10-12 23:30", "steps", "6000,1h00m")
+	suite.Require().Equal(exitOK, code)
+	code, _, _ = suite.run("add", "-at", "2026-10-12T15:30:00Z", "steps", "2000,1h00m")
+	suite.Require().Equal(exitOK, code)
+
+	code, stdout, _ = suite.run("report", "-from", "2026-10-12", "-to", "2026-10-13")
+	suite.Require().Equal(exitOK, code)
+	assert.Contains(suite.T(), stdout, "12.10.2026: шагов 6000,")
+	assert.Contains(suite.T(), stdout, "13.10.2026: шагов 2000,")
+}
+
+func (suite *TrackerTestSuite) TestBodyTimezone() {
+	// Пояс профиля на много часов отличается от пояса системы в тестах.
+	tz := "Asia/Tokyo"
+	if _, offset := time.Now().Zone(); offset >= 6*60*60 {
+		tz = "America/Los_Angeles"
+	}
+	loc, err := time.LoadLocation(tz)
+	suite.Require().NoError(err)
+
+	code, _, _ := suite.run("profile", "-weight", "75", "-height", "1.75", "-tz", tz)
+	suite.Require().Equal(exitOK, code)
+	code, _, _ = suite.run("body", "-date", "2020-10-01", "-weight", "75")
+	suite.Require().Equal(exitOK, code)
+	code, _, _ = suite.run("body", "-date", "2020-10-13", "-weight", "60")
+	suite.Require().Equal(exitOK, code)
+
+	// Новый вес действует с полуночи 13 октября по поясу профиля, а не системы.
+	code, _, _ = suite.run("add", "-at", "2020-10-12 23:30", "training", "6000,Бег,1h00m")
+	suite.Require().Equal(exitOK, code)
+	code, _, _ = suite.run("add", "-at", "2020-10-13 00:30", "training", "6000,Бег,1h00m")
+	suite.Require().Equal(exitOK, code)
+	code, stdout, _ := suite.run("report", "-from", "2020-10-12", "-to", "2020-10-13")
+	suite.Require().Equal(exitOK, code)
+	assert.Contains(suite.T(), stdout, "12.10.2020: шагов 0, 0.00 км, 0.00 ккал; тренировок 1: 4.72 км, 354.38 ккал; темп бега 12:42 мин/км\n")
+	assert.Contains(suite.T(), stdout, "13.10.2020: шагов 0, 0.00 км, 0.00 ккал; тренировок 1: 4.72 км, 283.50 ккал; темп бега 12:42 мин/км\n")
+
+	// Измерение без даты записывается сегодняшним днём по поясу профиля.
+	code, stdout, _ = suite.run("body", "-weight", "61")
+	suite.Require().Equal(exitOK, code)
+	assert.True(suite.T(), strings.HasPrefix(stdout, "Измерение сохранено: "+time.Now().In(loc).Format("02.01.2006")+": вес 61.0 кг"), stdout)
+
+	a, err := (&app{root: suite.dir, cfg: config.Default(), logger: slog.Default()}).withUser("")
+	suite.Require().NoError(err)
+	stamp, err := a.screenStamp()
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), time.Now().In(loc).Format(dateLayout), stamp.date, "сегодня на экране — по поясу профиля")
+}
+
+func (suite *TrackerTestSuite) TestSleepAndRest() {
+	code, _, _ := suite.run("profile", "-weight", "75", "-height", "1.75")
+	suite.Require().Equal(exitOK, code)
+
+	code, stdout, _ := suite.run("add", "-at", "2026-10-12 07:10", "sleep", "23:15,07:05,quality=4")
+	suite.Require().Equal(exitOK, code)
+	assert.Equal(suite.T(), "Сон: 23:15 — 07:05, 7.83 ч.\nКачество сна: 4 из 5.\n", stdout)
+	code, stdout, _ = suite.run("add", "-at", "2026-10-13", "rest", "после полумарафона")
+	suite.Require().Equal(exitOK, code)
+	assert.Equal(suite.T(), "День отдыха: после полумарафона.\n", stdout)
+
+	code, _, _ = suite.run("add", "sleep", "23:15,07:05,quality=9")
+	assert.Equal(suite.T(), exitError, code)
+
+	code, stdout, _ = suite.run("report", "-from", "2026-10-12", "-to", "2026-10-13")
+	suite.Require().Equal(exitOK, code)
+	assert.Contains(suite.T(), stdout, "12.10.2026: шагов 0, 0.00 км, 0.00 ккал; сон 7.83 ч., качество 4.0 из 5\n")
+	assert.Contains(suite.T(), stdout, "13.10.2026: шагов 0, 0.00 км, 0.00 ккал; день отдыха: после полумарафона\n")
+}
+
+func (suite *TrackerTestSuite) TestImportRestBatch() {
+	code, _, _ := suite.run("profile", "-weight", "75", "-height", "1.75")
+	suite.Require().Equal(exitOK, code)
+
+	src := filepath.Join(suite.T().TempDir(), "rest.csv")
+	suite.Require().NoError(os.WriteFile(src, []byte("time,kind,record\n2026-10-13T12:00:00Z,rest,после забега\n"), 0o600))
+	code, _, _ = suite.run("import", "-batch", "b1", src)
+	suite.Require().Equal(exitOK, code)
+
+	// Партия импорта — метаданные записи, а не часть пояснения.
+	code, stdout, _ := suite.run("report", "-from", "2026-10-13", "-to", "2026-10-13")
+	suite.Require().Equal(exitOK, code)
+	assert.Contains(suite.T(), stdout, "13.10.2026: шагов 0, 0.00 км, 0.00 ккал; день отдыха: после забега\n")
+	assert.NotContains(suite.T(), stdout, "batch=")
+}
+
+func (suite *TrackerTestSuite) TestBalance() {
+	code, _, _ := suite.run("profile", "-weight", "80", "-height", "1.8")
+	suite.Require().Equal(exitOK, code)
+	today := time.Now().Format(dateLayout)
+
+	code, stdout, _ := suite.run("add", "-at", today, "food", "Завтрак,520,protein=30,fat=12,carbs=60")
+	suite.Require().Equal(exitOK, code)
+	assert.Equal(suite.T(), "Приём пищи: Завтрак, 520.00 ккал.\nСостав: белки 30.0 г, жиры 12.0 г, углеводы 60.0 г.\n", stdout)
+	code, _, _ = suite.run("add", "-at", today, "food", "Ужин,1800")
+	suite.Require().Equal(exitOK, code)
+
+	code, _, stderr := suite.run("balance")
+	assert.Equal(suite.T(), exitError, code)
+	assert.Contains(suite.T(), stderr, "-birth-year")
+
+	code, _, _ = suite.run("profile", "-sex", "male", "-birth-year", "1990")
+	suite.Require().Equal(exitOK, code)
+	code, stdout, _ = suite.run("profile")
+	suite.Require().Equal(exitOK, code)
+	assert.Contains(suite.T(), stdout, "Пол: male\nГод рождения: 1990\n")
+
+	code, stdout, _ = suite.run("balance", "-weeks", "2")
+	suite.Require().Equal(exitOK, code)
+	bmr := 10*80 + 6.25*180 - 5*float64(time.Now().Year()-1990) + 5
+	assert.Contains(suite.T(), stdout, fmt.Sprintf("%s %8.0f %8.0f %10.0f %8.0f\n",
+		time.Now().Format("02.01.2006"), 2320.0, bmr, 0.0, 2320-bmr))
+	assert.Contains(suite.T(), stdout, "Тренд: средний баланс меняется на +0 ккал в день за неделю\n")
+
+	code, _, _ = suite.run("balance", "-weeks", "0")
+	assert.Equal(suite.T(), exitUsage, code)
+	code, _, _ = suite.run("profile", "-sex", "m")
+	assert.Equal(suite.T(), exitError, code)
+}
+
+func (suite *TrackerTestSuite) TestChart() {
+	code, _, _ := suite.run("profile", "-weight", "75", "-height", "1.75")
+	suite.Require().Equal(exitOK, code)
+	code, _, _ = suite.run("add", "-at", "2026-10-12 18:00", "training", "6000,Бег,1h00m")
+	suite.Require().Equal(exitOK, code)
+
+	code, stdout, _ := suite.run("chart", "-kind", "pace", "-from", "2026-10-12", "-to", "2026-10-13")
+	suite.Require().Equal(exitOK, code)
+	assert.Contains(suite.T(), stdout, "Темп беговых тренировок")
+
+	out := filepath.Join(suite.T().TempDir(), "steps.png")
+	code, _, _ = suite.run("chart", "-format", "png", "-o", out)
+	suite.Require().Equal(exitOK, code)
+	data, err := os.ReadFile(out)
+	suite.Require().NoError(err)
+	assert.True(suite.T(), bytes.HasPrefix(data, []byte("\x89PNG")))
+
+	code, _, _ = suite.run("chart", "-kind", "weight")
+	assert.Equal(suite.T(), exitUsage, code)
+}
+
+func (suite *TrackerTestSuite) TestEditDeleteUndo() {
+	code, _, _ := suite.run("profile", "-weight", "75", "-height", "1.75")
+	suite.Require().Equal(exitOK, code)
+	code, _, _ = suite.run("add", "-at", "2026-10-12 08:00", "steps", "78920,1h00m")
+	suite.Require().Equal(exitOK, code)
+	code, _, _ = suite.run("add", "-at", "2026-10-12 18:00", "training", "6000,Бег,1h00m")
+	suite.Require().Equal(exitOK, code)
+
+	code, stdout, _ := suite.run("edit", "-at", "2026-10-12 08:00", "-by", "anna", "7892,1h00m")
+	suite.Require().Equal(exitOK, code)
+	assert.Contains(suite.T(), stdout, "Количество шагов: 7892.\n")
+
+	code, _, _ = suite.run("delete", "-at", "2026-10-12 18:00", "-by", "anna")
+	suite.Require().Equal(exitOK, code)
+
+	code, stdout, _ = suite.run("report", "-from", "2026-10-12", "-to", "2026-10-12")
+	suite.Require().Equal(exitOK, code)
+	assert.Contains(suite.T(), stdout, "Итого: шагов 7892,")
+	assert.Contains(suite.T(), stdout, "тренировок 0:")
+
+	code, stdout, _ = suite.run("undo", "-by", "anna")
+	suite.Require().Equal(exitOK, code)
+	assert.Equal(suite.T(), "Отменено изменение #2 (delete)\n", stdout)
+
+	code, stdout, _ = suite.run("export", "-format", "text")
+	suite.Require().Equal(exitOK, code)
+	assert.Equal(suite.T(), "2026-10-12T08:00:00Z\tsteps\t7892,1h00m\n2026-10-12T18:00:00Z\ttraining\t6000,Бег,1h00m\n", stdout)
+
+	code, stdout, _ = suite.run("audit")
+	suite.Require().Equal(exitOK, code)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	suite.Require().Len(lines, 3)
+	assert.Contains(suite.T(), lines[0], "anna: edit 12.10.2026 08:00 steps 78920,1h00m → 7892,1h00m")
+	assert.Contains(suite.T(), lines[2], "anna: undo #2 ")
+
+	code, _, _ = suite.run("undo")
+	suite.Require().Equal(exitOK, code)
+	code, _, _ = suite.run("undo")
+	assert.Equal(suite.T(), exitError, code, "отмены не отменяются")
+
+	code, _, _ = suite.run("edit", "-at", "2026-10-13 08:00", "100,1m")
+	assert.Equal(suite.T(), exitError, code)
+	code, _, _ = suite.run("edit", "-at", "2026-10-12 08:00", "0,1h00m")
+	assert.Equal(suite.T(), exitError, code)
+	code, _, _ = suite.run("delete")
+	assert.Equal(suite.T(), exitUsage, code)
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/body"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/zone"
+)
+
+const profileUsage = `[-weight кг] [-height м] [-goal шагов] [-tz пояс] [-sex male|female] [-birth-year год]
+
+Без флагов печатает профиль, включая дневную цель по шагам. С флагами сохраняет указанные значения,
+остальные остаются прежними. Новые вес и рост записываются в историю измерений
+сегодняшним днём и не меняют расчёт прошлых записей. Часовой пояс IANA,
+например Europe/Berlin, определяет границы дней в отчётах; пустой — местный пояс системы.
+Пол и год рождения нужны для расчёта основного обмена в команде balance.`
+
+func runProfile(a *app, args []string) error {
+	fs := a.flagSet("profile", profileUsage)
+	weight := fs.Float64("weight", 0, "вес, кг")
+	height := fs.Float64("height", 0, "рост, м")
+	goal := fs.Int("goal", 0, "дневная цель по шагам, 0 — по умолчанию")
+	tz := fs.String("tz", "", "часовой пояс IANA, например Europe/Berlin; пустой — местный")
+	sex := fs.String("sex", "", "пол для расчёта основного обмена: male или female")
+	birthYear := fs.Int("birth-year", 0, "год рождения для расчёта основного обмена")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageError("лишние аргументы: %v", fs.Args())
+	}
+
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	p, err := profile.Load(a.profilePath())
+	if len(set) == 0 {
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stdout, "Вес: %.1f кг\nРост: %.2f м\nЦель: %d шагов в день\nЧасовой пояс: %s\n", p.Weight, p.Height, p.Goal(), p.Location())
+		if p.Sex != "" {
+			fmt.Fprintf(a.stdout, "Пол: %s\n", p.Sex)
+		}
+		if p.BirthYear != 0 {
+			fmt.Fprintf(a.stdout, "Год рождения: %d\n", p.BirthYear)
+		}
+		return nil
+	}
+	if err != nil && !errors.Is(err, profile.ErrNotFound) {
+		return err
+	}
+	old := p
+
+	if set["weight"] {
+		p.Weight = *weight
+	}
+	if set["height"] {
+		p.Height = *height
+	}
+	if set["goal"] {
+		p.StepGoal = *goal
+	}
+	if set["tz"] {
+		p.Timezone = *tz
+	}
+	if set["sex"] {
+		p.Sex = profile.Sex(*sex)
+	}
+	if set["birth-year"] {
+		p.BirthYear = *birthYear
+	}
+	if err := a.ensureDir(); err != nil {
+		return err
+	}
+	if err := profile.Save(a.profilePath(), p); err != nil {
+		return err
+	}
+	if set["weight"] || set["height"] {
+		if err := a.recordBody(old, p, set["weight"], set["height"]); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(a.stdout, "Профиль сохранён: вес %.1f кг, рост %.2f м, цель %d шагов\n", p.Weight, p.Height, p.Goal())
+	return nil
+}
+
+// recordBody дописывает в историю измерений сегодняшние вес и рост из профиля p,
+// чтобы новые значения не применялись к записям прошлых дней. Если история
+// пуста, прежние значения old сохраняются вчерашним днём и действуют для всех
+// более ранних записей.
+func (a *app) recordBody(old, p profile.Profile, weight, height bool) error {
+	h, err := a.loadBody()
+	if err != nil {
+		return err
+	}
+	today := zone.StartOfDay(time.Now(), p.Location())
+	if len(h) == 0 && old.Weight > 0 {
+		if h, err = h.Add(body.Measurement{Date: shiftDays(today, -1), Weight: old.Weight, Height: old.Height}); err != nil {
+			return err
+		}
+	}
+
+	m := body.Measurement{Date: today}
+	if weight {
+		m.Weight = p.Weight
+	}
+	if height {
+		m.Height = p.Height
+	}
+	if h, err = h.Add(m); err != nil {
+		return err
+	}
+	return body.Save(a.bodyPath(), h)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Yandex-Practicum/tracker/internal/journal"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+)
+
+const reportUsage = `[-from 2006-01-02] [-to 2006-01-02] [-source имя]
+
+Печатает показатели по дням и итог за период. По умолчанию — последние
+семь дней, включая сегодняшний. Записи с ошибками пропускаются и пишутся в журнал.
+С -source учитываются только записи с этим источником, устройством или приложением.`
+
+func runReport(a *app, args []string) error {
+	fs := a.flagSet("report", reportUsage)
+	fromStr := fs.String("from", "", "первый день периода")
+	toStr := fs.String("to", "", "последний день периода, по умолчанию сегодня")
+	source := fs.String("source", "", "источник, устройство или приложение записей")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageError("лишние аргументы: %v", fs.Args())
+	}
+
+	r, err := a.buildReport(*fromStr, *toStr, *source)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(a.stdout, r.Text())
+	return nil
+}
+
+// buildReport строит отчёт по журналу за период, заданный датами в формате "2006-01-02".
+// Непустой source оставляет только записи с этим источником.
+func (a *app) buildReport(fromStr, toStr, source string) (report.Report, error) {
+	from, to, err := parseRange(fromStr, toStr, a.location())
+	if err != nil {
+		return report.Report{}, err
+	}
+	p, err := a.loadProfile()
+	if err != nil {
+		return report.Report{}, err
+	}
+	entries, err := a.loadJournal(source)
+	if err != nil {
+		return report.Report{}, err
+	}
+	b, err := a.reportBuilder()
+	if err != nil {
+		return report.Report{}, err
+	}
+
+	return b.Build(entries, p, from, to), nil
+}
+
+// reportBuilder возвращает построитель отчётов с коэффициентами, журналом
+// и историей измерений приложения.
+func (a *app) reportBuilder() (report.Builder, error) {
+	h, err := a.loadBody()
+	if err != nil {
+		return report.Builder{}, err
+	}
+	return report.NewBuilder(a.cfg, a.logger).WithBody(h), nil
+}
+
+// loadJournal читает журнал; непустой source оставляет только записи
+// с этим источником, устройством или приложением.
+func (a *app) loadJournal(source string) ([]journal.Entry, error) {
+	entries, err := journal.Load(a.journalPath())
+	if err != nil || source == "" {
+		return entries, err
+	}
+	return journal.FromSource(entries, source), nil
+}
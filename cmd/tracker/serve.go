@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/Yandex-Practicum/tracker/internal/journal"
+	"github.com/Yandex-Practicum/tracker/internal/parseerr"
+)
+
+const serveUsage = `[-addr :8080] [-any-user]
+
+Запускает HTTP-сервер только для чтения:
+  GET /report?from=2006-01-02&to=2006-01-02 — отчёт, как у команды report;
+  GET /export?format=csv|jsonl|text         — выгрузка журнала.
+Сервер отдаёт данные только пользователя, заданного флагом -user. Параметр
+user, выбирающий другого пользователя, принимается лишь с флагом -any-user.
+Параметр source оставляет записи одного источника, устройства или приложения.`
+
+// errForbidden возвращается на запрос данных другого пользователя без -any-user.
+var errForbidden = errors.New("доступ к данным другого пользователя запрещён: запустите сервер с флагом -any-user")
+
+func runServe(a *app, args []string) error {
+	fs := a.flagSet("serve", serveUsage)
+	addr := fs.String("addr", "localhost:8080", "адрес для входящих соединений")
+	anyUser := fs.Bool("any-user", false, "разрешить выбор пользователя параметром запроса user")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageError("лишние аргументы: %v", fs.Args())
+	}
+
+	a.logger.Info("сервер запущен", slog.String("addr", *addr), slog.Bool("any_user", *anyUser))
+	return http.ListenAndServe(*addr, a.handler(*anyUser))
+}
+
+// handler возвращает обработчик HTTP-запросов сервера. Если anyUser ложно,
+// запросы с параметром user отклоняются.
+func (a *app) handler(anyUser bool) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /report", func(w http.ResponseWriter, req *http.Request) {
+		a, err := a.requestUser(req, anyUser)
+		if err != nil {
+			a.httpError(w, req, err)
+			return
+		}
+		q := req.URL.Query()
+		r, err := a.buildReport(q.Get("from"), q.Get("to"), q.Get("source"))
+		if err != nil {
+			a.httpError(w, req, err)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprint(w, r.Text())
+	})
+	mux.HandleFunc("GET /export", func(w http.ResponseWriter, req *http.Request) {
+		a, err := a.requestUser(req, anyUser)
+		if err != nil {
+			a.httpError(w, req, err)
+			return
+		}
+		format := req.URL.Query().Get("format")
+		if format == "" {
+			format = string(journal.FormatCSV)
+		}
+		f, err := journal.ParseFormat(format)
+		if err != nil {
+			a.httpError(w, req, usageError("%v", err))
+			return
+		}
+		entries, err := a.loadJournal(req.URL.Query().Get("source"))
+		if err != nil {
+			a.httpError(w, req, err)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if err := journal.Encode(w, entries, f); err != nil {
+			a.logger.Error("не удалось отправить выгрузку", parseerr.Attr(err))
+		}
+	})
+	return mux
+}
+
+// requestUser возвращает окружение пользователя из параметра запроса user.
+// Без разрешения anyUser параметр user, называющий другого пользователя,
+// отклоняется с errForbidden.
+// При ошибке возвращает исходное окружение, чтобы ответить через него.
+func (a *app) requestUser(req *http.Request, anyUser bool) (*app, error) {
+	name := req.URL.Query().Get("user")
+	if name == "" || name == a.user {
+		return a, nil
+	}
+	if !anyUser {
+		return a, errForbidden
+	}
+	u, err := a.withUser(name)
+	if err != nil {
+		return a, err
+	}
+	return u, nil
+}
+
+// httpError отвечает ошибкой: 400 для ошибок аргументов, 403 для чужих данных,
+// 500 для остальных.
+func (a *app) httpError(w http.ResponseWriter, req *http.Request, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, errUsage):
+		status = http.StatusBadRequest
+	case errors.Is(err, errForbidden):
+		status = http.StatusForbidden
+	}
+	a.logger.Error("ошибка запроса", slog.String("path", req.URL.Path), slog.Int("status", status), parseerr.Attr(err))
+	http.Error(w, err.Error(), status)
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/htmlreport"
+	"github.com/Yandex-Practicum/tracker/internal/ical"
+	"github.com/Yandex-Practicum/tracker/internal/journal"
+	"github.com/Yandex-Practicum/tracker/internal/meta"
+)
+
+// Форматы выгрузки рассчитанных показателей, а не записей журнала.
+const (
+	formatHTML = "html" // отчёт одной HTML-страницей.
+	formatICS  = "ics"  // тренировки календарём iCalendar.
+)
+
+const importUsage = `[-format text|csv|jsonl] [-batch id] <файл>
+
+Добавляет в журнал записи из файла ("-" — стандартный ввод). Каждая запись
+проверяется; при первой ошибке журнал не изменяется. С -batch записи
+помечаются идентификатором партии импорта, если он у них ещё не указан.`
+
+const exportUsage = `[-format text|csv|jsonl|html|ics] [-o файл] [-from 2006-01-02] [-to 2006-01-02] [-source имя] [-athlete имя]
+
+Выгружает записи журнала в файл или на стандартный вывод. Без -from и -to
+выгружается весь журнал, с -source — только записи с этим источником,
+устройством или приложением. Метаданные записей сохраняются во всех форматах.
+
+Формат html выгружает не записи, а отчёт за период (по умолчанию — последние
+семь дней) одной страницей со сводкой по дням, тренировками, прогрессом
+к цели и диаграммами.
+
+Формат ics выгружает тренировки событиями календаря iCalendar; без -from
+и -to — все тренировки журнала.`
+
+func runImport(a *app, args []string) error {
+	fs := a.flagSet("import", importUsage)
+	format := fs.String("format", string(journal.FormatCSV), "формат файла")
+	batch := fs.String("batch", "", "идентификатор партии импорта")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageError("ожидается один файл, получено аргументов: %d", fs.NArg())
+	}
+	f, err := journal.ParseFormat(*format)
+	if err != nil {
+		return usageError("%v", err)
+	}
+
+	var r io.Reader = os.Stdin
+	if name := fs.Arg(0); name != "-" {
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		r = file
+	}
+
+	imported, err := journal.Decode(r, f)
+	if err != nil {
+		return err
+	}
+	for i, e := range imported {
+		if *batch != "" {
+			if imported[i].Record, err = meta.WithBatch(e.Record, *batch); err != nil {
+				return usageError("%v", err)
+			}
+		}
+		if _, err := a.describe(imported[i]); err != nil {
+			return fmt.Errorf("запись %d (%s): %w", e.Line, e.Record, err)
+		}
+	}
+
+	if err := a.ensureDir(); err != nil {
+		return err
+	}
+	entries, err := journal.Load(a.journalPath())
+	if err != nil {
+		return err
+	}
+	if err := journal.Save(a.journalPath(), append(entries, imported...)); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, "Загружено записей: %d\n", len(imported))
+	return nil
+}
+
+func runExport(a *app, args []string) error {
+	fs := a.flagSet("export", exportUsage)
+	format := fs.String("format", string(journal.FormatCSV), "формат файла")
+	out := fs.String("o", "-", "файл для записи, \"-\" — стандартный вывод")
+	fromStr := fs.String("from", "", "первый день периода")
+	toStr := fs.String("to", "", "последний день периода")
+	source := fs.String("source", "", "источник, устройство или приложение записей")
+	athlete := fs.String("athlete", "", "имя спортсмена в заголовке отчёта html")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageError("лишние аргументы: %v", fs.Args())
+	}
+	switch *format {
+	case formatHTML:
+		return exportHTML(a, *out, *fromStr, *toStr, *source, *athlete)
+	case formatICS:
+		return exportICS(a, *out, *fromStr, *toStr, *source)
+	}
+	f, err := journal.ParseFormat(*format)
+	if err != nil {
+		return usageError("%v", err)
+	}
+
+	entries, err := a.loadJournal(*source)
+	if err != nil {
+		return err
+	}
+	if *fromStr != "" || *toStr != "" {
+		from, to, err := parseRange(*fromStr, *toStr, a.location())
+		if err != nil {
+			return err
+		}
+		entries = journal.Between(entries, from, to)
+	}
+
+	return writeOutput(a, *out, func(w io.Writer) error {
+		return journal.Encode(w, entries, f)
+	})
+}
+
+// exportHTML выгружает отчёт за период в виде HTML-страницы.
+func exportHTML(a *app, out, fromStr, toStr, source, athlete string) error {
+	r, err := a.buildReport(fromStr, toStr, source)
+	if err != nil {
+		return err
+	}
+	p, err := a.loadProfile()
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(a, out, func(w io.Writer) error {
+		return htmlreport.Write(w, r, p, athlete)
+	})
+}
+
+// exportICS выгружает тренировки за период или, без границ, за всё время календарём iCalendar.
+// Непустой source оставляет только тренировки с этим источником.
+func exportICS(a *app, out, fromStr, toStr, source string) error {
+	p, err := a.loadProfile()
+	if err != nil {
+		return err
+	}
+	entries, err := a.loadJournal(source)
+	if err != nil {
+		return err
+	}
+
+	from, to := historyRange(entries, p.Location())
+	if fromStr != "" || toStr != "" {
+		from, to, err = parseRange(fromStr, toStr, p.Location())
+		if err != nil {
+			return err
+		}
+	}
+
+	b, err := a.reportBuilder()
+	if err != nil {
+		return err
+	}
+
+	r := b.Build(entries, p, from, to)
+	return writeOutput(a, out, func(w io.Writer) error {
+		return ical.Write(w, r, time.Now())
+	})
+}
+
+// writeOutput вызывает write для файла name или стандартного вывода, если name равно "-".
+func writeOutput(a *app, name string, write func(io.Writer) error) error {
+	if name == "-" {
+		return write(a.stdout)
+	}
+
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/dashboard"
+	"github.com/Yandex-Practicum/tracker/internal/journal"
+	"github.com/Yandex-Practicum/tracker/internal/zone"
+)
+
+const tuiUsage = `[-interval 1s]
+
+Открывает полноэкранную сводку за сегодня: шаги, дистанцию, калории,
+прогресс к цели, шаги по часам и тренировки. Экран перерисовывается,
+когда меняется журнал, профиль или наступает новый день. Выход — Ctrl+C.`
+
+// fileStamp — признаки изменения файла.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// screenStamp — всё, от чего зависит содержимое экрана.
+type screenStamp struct {
+	journal, profile, body fileStamp
+	date                   string
+}
+
+func runTUI(a *app, args []string) error {
+	fs := a.flagSet("tui", tuiUsage)
+	interval := fs.Duration("interval", time.Second, "период проверки журнала на изменения")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageError("лишние аргументы: %v", fs.Args())
+	}
+	if *interval <= 0 {
+		return usageError("период проверки должен быть больше нуля: %s", *interval)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Fprint(a.stdout, dashboard.Start)
+	defer fmt.Fprint(a.stdout, dashboard.Stop)
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+
+	var last screenStamp
+	for {
+		cur, err := a.screenStamp()
+		if err != nil {
+			return err
+		}
+		if cur != last {
+			frame, err := a.dashboardFrame()
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(a.stdout, frame)
+			last = cur
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// screenStamp возвращает текущие признаки изменения журнала, профиля, измерений и даты.
+func (a *app) screenStamp() (screenStamp, error) {
+	j, err := stat(a.journalPath())
+	if err != nil {
+		return screenStamp{}, err
+	}
+	p, err := stat(a.profilePath())
+	if err != nil {
+		return screenStamp{}, err
+	}
+	b, err := stat(a.bodyPath())
+	if err != nil {
+		return screenStamp{}, err
+	}
+	return screenStamp{journal: j, profile: p, body: b, date: time.Now().In(a.location()).Format(dateLayout)}, nil
+}
+
+// stat возвращает признаки изменения файла; для отсутствующего файла — нулевое значение.
+func stat(path string) (fileStamp, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fileStamp{}, nil
+	}
+	if err != nil {
+		return fileStamp{}, err
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}, nil
+}
+
+// dashboardFrame строит кадр экрана за сегодняшний день.
+func (a *app) dashboardFrame() (string, error) {
+	p, err := a.loadProfile()
+	if err != nil {
+		return "", err
+	}
+	entries, err := journal.Load(a.journalPath())
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().In(p.Location())
+	from := zone.StartOfDay(now, p.Location())
+	b, err := a.reportBuilder()
+	if err != nil {
+		return "", err
+	}
+	r := b.Build(entries, p, from, shiftDays(from, 1))
+
+	return dashboard.Render(dashboard.State{Day: r.Days[0], Goal: p.Goal(), Updated: now}), nil
+}
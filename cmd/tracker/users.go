@@ -0,0 +1,38 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const usersUsage = `
+
+Печатает пользователей, у которых есть данные в каталоге установки.
+Пользователь выбирается общим флагом -user или переменной TRACKER_USER.`
+
+func runUsers(a *app, args []string) error {
+	fset := a.flagSet("users", usersUsage)
+	if err := parseFlags(fset, args); err != nil {
+		return err
+	}
+	if fset.NArg() != 0 {
+		return usageError("лишние аргументы: %v", fset.Args())
+	}
+
+	entries, err := os.ReadDir(filepath.Join(a.root, usersDir))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("не удалось прочитать список пользователей: %w", err)
+	}
+	for _, e := range entries {
+		if e.IsDir() && validateUser(e.Name()) == nil {
+			fmt.Fprintln(a.stdout, e.Name())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package achievements
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+)
+
+const (
+	// tenK — дистанция забега для значка «Первые 10 км», км.
+	tenK = 10
+	// millionSteps — число шагов за всё время для значка «Миллион шагов».
+	millionSteps = 1_000_000
+	// dateLayout — формат даты в сводке.
+	dateLayout = "02.01.2006"
+)
+
+// Badge — значок за достижение.
+type Badge string
+
+const (
+	BadgeFirst10K     Badge = "Первые 10 км"
+	BadgeMillionSteps Badge = "Миллион шагов"
+)
+
+// Record — личный рекорд.
+type Record struct {
+	Value float64
+	Date  time.Time
+}
+
+// Stats — серии, рекорды и значки по истории. Шаги считаются по пакетам
+// дневной активности, как и выполнение дневной цели в отчётах.
+type Stats struct {
+	// Streak — текущая серия дней подряд с выполненной целью. Серия не
+	// прерывается, пока не закончился последний день истории, а запланированный
+	// день отдыха без выполненной цели не прерывает её и не продлевает.
+	Streak int
+	// BestStreak — самая длинная серия за всё время.
+	BestStreak int
+
+	LongestRun   Record // км.
+	FastestSpeed Record // средняя скорость тренировки, км/ч; без велотренировок.
+	MostSteps    Record // шагов за день.
+
+	LifetimeSteps int
+	// Badges — полученные значки и дни их получения.
+	Badges map[Badge]time.Time
+}
+
+// Compute рассчитывает достижения по отчёту r за всю историю с дневной целью goal шагов.
+// Последний день отчёта считается текущим.
+func Compute(r report.Report, goal int) Stats {
+	s := Stats{Badges: make(map[Badge]time.Time)}
+
+	run, prevRun := 0, 0
+	for _, d := range r.Days {
+		prevRun = run
+		switch {
+		case d.Steps >= goal:
+			run++
+		case d.Rest != nil:
+			// День отдыха запланирован: в отличие от пропуска, серия сохраняется.
+		default:
+			run = 0
+		}
+		s.BestStreak = max(s.BestStreak, run)
+
+		if d.Steps > int(s.MostSteps.Value) {
+			s.MostSteps = Record{Value: float64(d.Steps), Date: d.Date}
+		}
+		s.LifetimeSteps += d.Steps
+		if _, ok := s.Badges[BadgeMillionSteps]; !ok && s.LifetimeSteps >= millionSteps {
+			s.Badges[BadgeMillionSteps] = d.Date
+		}
+
+		for _, tr := range d.Trainings {
+			res := tr.Result
+			if res.Type() != spentcalories.CyclingType && res.Speed > s.FastestSpeed.Value {
+				s.FastestSpeed = Record{Value: res.Speed, Date: d.Date}
+			}
+			if res.Type() != spentcalories.RunningType {
+				continue
+			}
+			if res.Distance > s.LongestRun.Value {
+				s.LongestRun = Record{Value: res.Distance, Date: d.Date}
+			}
+			if _, ok := s.Badges[BadgeFirst10K]; !ok && res.Distance >= tenK {
+				s.Badges[BadgeFirst10K] = d.Date
+			}
+		}
+	}
+
+	s.Streak = run
+	if run == 0 {
+		// Сегодня цель ещё не выполнена: серия, закончившаяся вчера, продолжается.
+		s.Streak = prevRun
+	}
+	return s
+}
+
+// New возвращает сообщения о достижениях, которые есть в after, но не было в before.
+// Первое значение показателя рекордом не считается.
+func New(before, after Stats) []string {
+	var msgs []string
+	if after.Streak > before.Streak && after.Streak > 1 {
+		msg := fmt.Sprintf("Серия: %d дн. подряд с выполненной целью", after.Streak)
+		if after.BestStreak > before.BestStreak && before.BestStreak > 0 {
+			msg += " — это рекорд"
+		}
+		msgs = append(msgs, msg)
+	}
+	if after.LongestRun.Value > before.LongestRun.Value && before.LongestRun.Value > 0 {
+		msgs = append(msgs, fmt.Sprintf("Новый рекорд: самый длинный бег %.2f км", after.LongestRun.Value))
+	}
+	if after.FastestSpeed.Value > before.FastestSpeed.Value && before.FastestSpeed.Value > 0 {
+		msgs = append(msgs, fmt.Sprintf("Новый рекорд: самая высокая скорость %.2f км/ч", after.FastestSpeed.Value))
+	}
+	if after.MostSteps.Value > before.MostSteps.Value && before.MostSteps.Value > 0 {
+		msgs = append(msgs, fmt.Sprintf("Новый рекорд: %.0f шагов за день", after.MostSteps.Value))
+	}
+	for _, b := range []Badge{BadgeFirst10K, BadgeMillionSteps} {
+		if _, had := before.Badges[b]; had {
+			continue
+		}
+		if _, has := after.Badges[b]; has {
+			msgs = append(msgs, fmt.Sprintf("Новый значок: %s", b))
+		}
+	}
+	return msgs
+}
+
+// Text возвращает сводку достижений в текстовом виде.
+func (s Stats) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Текущая серия: %d дн.\nЛучшая серия: %d дн.\n", s.Streak, s.BestStreak)
+	if s.LongestRun.Value > 0 {
+		fmt.Fprintf(&b, "Самый длинный бег: %.2f км (%s)\n", s.LongestRun.Value, s.LongestRun.Date.Format(dateLayout))
+	}
+	if s.FastestSpeed.Value > 0 {
+		fmt.Fprintf(&b, "Самая высокая скорость: %.2f км/ч (%s)\n", s.FastestSpeed.Value, s.FastestSpeed.Date.Format(dateLayout))
+	}
+	if s.MostSteps.Value > 0 {
+		fmt.Fprintf(&b, "Больше всего шагов за день: %.0f (%s)\n", s.MostSteps.Value, s.MostSteps.Date.Format(dateLayout))
+	}
+	fmt.Fprintf(&b, "Шагов за всё время: %d\n", s.LifetimeSteps)
+	for _, badge := range []Badge{BadgeFirst10K, BadgeMillionSteps} {
+		if at, ok := s.Badges[badge]; ok {
+			fmt.Fprintf(&b, "Значок «%s» (%s)\n", badge, at.Format(dateLayout))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,121 @@
+package achievements
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/recovery"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type AchievementsTestSuite struct {
+	suite.Suite
+}
+
+func TestAchievementsSuite(t *testing.T) {
+	suite.Run(t, new(AchievementsTestSuite))
+}
+
+// days возвращает отчёт с днями, начиная с 12.10.2026, и заданными шагами.
+func days(steps ...int) report.Report {
+	from := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	r := report.Report{From: from, To: from.AddDate(0, 0, len(steps))}
+	for i, s := range steps {
+		r.Days = append(r.Days, report.Day{Date: from.AddDate(0, 0, i), Steps: s})
+	}
+	return r
+}
+
+// run возвращает беговую тренировку с дистанцией dist км за час.
+func run(dist float64) report.Training {
+	return report.Training{Result: spentcalories.SessionResult{
+		Segments: []spentcalories.SegmentResult{{Segment: spentcalories.Segment{Type: spentcalories.RunningType}}},
+		Distance: dist,
+		Speed:    dist,
+	}}
+}
+
+func (suite *AchievementsTestSuite) TestStreak() {
+	s := Compute(days(12000, 12000, 12000, 500, 11000, 10000), 10000)
+	assert.Equal(suite.T(), 2, s.Streak)
+	assert.Equal(suite.T(), 3, s.BestStreak)
+
+	// Сегодняшняя цель ещё не выполнена — вчерашняя серия не прерывается.
+	s = Compute(days(11000, 10000, 300), 10000)
+	assert.Equal(suite.T(), 2, s.Streak)
+
+	s = Compute(days(11000, 0, 300), 10000)
+	assert.Equal(suite.T(), 0, s.Streak)
+}
+
+func (suite *AchievementsTestSuite) TestStreakRestDays() {
+	// Запланированный отдых сохраняет серию, а пропуск прерывает.
+	r := days(12000, 0, 11000, 0, 10000)
+	r.Days[1].Rest = &recovery.Rest{Note: "после полумарафона"}
+	s := Compute(r, 10000)
+	assert.Equal(suite.T(), 1, s.Streak)
+	assert.Equal(suite.T(), 2, s.BestStreak, "день отдыха не продлевает серию")
+
+	r = days(12000, 0)
+	r.Days[1].Rest = &recovery.Rest{Note: "восстановление"}
+	assert.Equal(suite.T(), 1, Compute(r, 10000).Streak)
+}
+
+func (suite *AchievementsTestSuite) TestRecordsAndBadges() {
+	r := days(5000, 990000, 8000)
+	r.Days[0].Trainings = []report.Training{run(8)}
+	r.Days[2].Trainings = []report.Training{run(10.5)}
+
+	s := Compute(r, 10000)
+	assert.Equal(suite.T(), Record{Value: 10.5, Date: r.Days[2].Date}, s.LongestRun)
+	assert.Equal(suite.T(), 10.5, s.FastestSpeed.Value)
+	assert.Equal(suite.T(), Record{Value: 990000, Date: r.Days[1].Date}, s.MostSteps)
+	assert.Equal(suite.T(), 1003000, s.LifetimeSteps)
+	assert.Equal(suite.T(), map[Badge]time.Time{
+		BadgeFirst10K:     r.Days[2].Date,
+		BadgeMillionSteps: r.Days[2].Date,
+	}, s.Badges)
+}
+
+func (suite *AchievementsTestSuite) TestNew() {
+	before := days(12000, 12000, 300)
+	before.Days[0].Trainings = []report.Training{run(8)}
+	after := days(12000, 12000, 13000)
+	after.Days[0].Trainings = []report.Training{run(8)}
+	after.Days[2].Trainings = []report.Training{run(10)}
+
+	got := New(Compute(before, 10000), Compute(after, 10000))
+	assert.Equal(suite.T(), []string{
+		"Серия: 3 дн. подряд с выполненной целью — это рекорд",
+		"Новый рекорд: самый длинный бег 10.00 км",
+		"Новый рекорд: самая высокая скорость 10.00 км/ч",
+		"Новый рекорд: 13000 шагов за день",
+		"Новый значок: Первые 10 км",
+	}, got)
+
+	// Первое значение показателя рекордом не считается.
+	assert.Empty(suite.T(), New(Compute(days(0), 10000), Compute(days(500), 10000)))
+}
+
+func (suite *AchievementsTestSuite) TestText() {
+	r := days(12000)
+	r.Days[0].Trainings = []report.Training{run(10)}
+	got := Compute(r, 10000).Text()
+	assert.Contains(suite.T(), got, "Текущая серия: 1 дн.\n")
+	assert.Contains(suite.T(), got, "Самый длинный бег: 10.00 км (12.10.2026)\n")
+	assert.Contains(suite.T(), got, "Значок «Первые 10 км» (12.10.2026)\n")
+	assert.NotContains(suite.T(), got, "Миллион шагов")
+}
+
+func (suite *AchievementsTestSuite) TestFastestSpeedSkipsCycling() {
+	r := days(0)
+	r.Days[0].Trainings = []report.Training{run(8), {Result: spentcalories.SessionResult{
+		Segments: []spentcalories.SegmentResult{{Segment: spentcalories.Segment{Type: spentcalories.CyclingType}}},
+		Distance: 30,
+		Speed:    30,
+	}}}
+	assert.Equal(suite.T(), 8.0, Compute(r, 10000).FastestSpeed.Value)
+}
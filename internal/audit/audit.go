@@ -0,0 +1,264 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/journal"
+	"github.com/Yandex-Practicum/tracker/internal/parseerr"
+)
+
+// Action — вид изменения журнала.
+type Action string
+
+// Виды изменений.
+const (
+	ActionEdit   Action = "edit"   // запись заменена исправленной.
+	ActionDelete Action = "delete" // запись удалена.
+	ActionUndo   Action = "undo"   // отменено предыдущее изменение.
+)
+
+// Change — изменение журнала: кто и когда заменил запись Before на After.
+// У удаления пуста запись After, у отмены удаления — Before.
+type Change struct {
+	ID     int
+	Time   time.Time
+	Author string
+	Action Action
+	Before journal.Entry
+	After  journal.Entry
+	// Undoes — номер изменения, отменённого этим; 0, если это не отмена.
+	Undoes int
+}
+
+// jsonEntry — представление записи журнала в JSON.
+type jsonEntry struct {
+	Time   time.Time    `json:"time"`
+	Kind   journal.Kind `json:"kind"`
+	Record string       `json:"record"`
+}
+
+// jsonChange — представление изменения в JSON.
+type jsonChange struct {
+	ID     int        `json:"id"`
+	Time   time.Time  `json:"time"`
+	Author string     `json:"author,omitempty"`
+	Action Action     `json:"action"`
+	Before *jsonEntry `json:"before,omitempty"`
+	After  *jsonEntry `json:"after,omitempty"`
+	Undoes int        `json:"undoes,omitempty"`
+}
+
+// toJSON возвращает представление записи в JSON или nil для пустой записи.
+func toJSON(e journal.Entry) *jsonEntry {
+	if isZero(e) {
+		return nil
+	}
+	return &jsonEntry{Time: e.Time, Kind: e.Kind, Record: e.Record}
+}
+
+// fromJSON возвращает запись из представления в JSON.
+func fromJSON(je *jsonEntry) journal.Entry {
+	if je == nil {
+		return journal.Entry{}
+	}
+	return journal.Entry{Time: je.Time, Kind: je.Kind, Record: je.Record}
+}
+
+// isZero сообщает, пуста ли запись.
+func isZero(e journal.Entry) bool {
+	return e.Time.IsZero() && e.Kind == "" && e.Record == ""
+}
+
+// same сообщает, совпадают ли записи по времени, виду и данным.
+func same(a, b journal.Entry) bool {
+	return a.Time.Equal(b.Time) && a.Kind == b.Kind && a.Record == b.Record
+}
+
+// Read читает изменения по объекту JSON на строку.
+func Read(r io.Reader) ([]Change, error) {
+	var changes []Change
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		var jc jsonChange
+		if err := json.Unmarshal(scanner.Bytes(), &jc); err != nil {
+			return nil, fmt.Errorf("строка %d: %w", line, parseerr.New("", parseerr.KindSyntax, "некорректный JSON: %w", err))
+		}
+		changes = append(changes, Change{
+			ID:     jc.ID,
+			Time:   jc.Time,
+			Author: jc.Author,
+			Action: jc.Action,
+			Before: fromJSON(jc.Before),
+			After:  fromJSON(jc.After),
+			Undoes: jc.Undoes,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось прочитать историю изменений: %w", err)
+	}
+	return changes, nil
+}
+
+// WriteJSONL записывает изменения в формате, который читает Read.
+func WriteJSONL(w io.Writer, changes []Change) error {
+	enc := json.NewEncoder(w)
+	for _, c := range changes {
+		jc := jsonChange{
+			ID:     c.ID,
+			Time:   c.Time,
+			Author: c.Author,
+			Action: c.Action,
+			Before: toJSON(c.Before),
+			After:  toJSON(c.After),
+			Undoes: c.Undoes,
+		}
+		if err := enc.Encode(jc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteText записывает изменения по одному на строку, например
+// "#2 19.10.2026 10:15 anna: edit 12.10.2026 08:00 steps 78920,1h00m → 7892,1h00m".
+func WriteText(w io.Writer, changes []Change) error {
+	bw := bufio.NewWriter(w)
+	for _, c := range changes {
+		fmt.Fprintf(bw, "#%d %s %s: %s", c.ID, c.Time.Format(timeLayout), author(c.Author), c.Action)
+		if c.Undoes > 0 {
+			fmt.Fprintf(bw, " #%d", c.Undoes)
+		}
+		e := c.Before
+		if isZero(e) {
+			e = c.After
+		}
+		fmt.Fprintf(bw, " %s %s %s", e.Time.Format(timeLayout), e.Kind, recordText(c.Before))
+		if !isZero(c.Before) && !isZero(c.After) && !c.Before.Time.Equal(c.After.Time) {
+			fmt.Fprintf(bw, " → %s", c.After.Time.Format(timeLayout))
+		}
+		fmt.Fprintf(bw, " → %s\n", recordText(c.After))
+	}
+	return bw.Flush()
+}
+
+// timeLayout — формат моментов времени в текстовой истории.
+const timeLayout = "02.01.2006 15:04"
+
+// author возвращает автора изменения для отчёта.
+func author(name string) string {
+	if name == "" {
+		return "неизвестно"
+	}
+	return name
+}
+
+// recordText возвращает данные записи для отчёта: "—" для пустой записи.
+func recordText(e journal.Entry) string {
+	if isZero(e) {
+		return "—"
+	}
+	return e.Record
+}
+
+// Load читает историю изменений из файла. Отсутствующий файл считается пустой историей.
+func Load(path string) ([]Change, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось открыть историю изменений: %w", err)
+	}
+	defer f.Close()
+
+	changes, err := Read(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return changes, nil
+}
+
+// Append добавляет изменение в конец файла истории, создавая файл при необходимости.
+func Append(path string, c Change) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("не удалось открыть историю изменений: %w", err)
+	}
+	if err := WriteJSONL(f, []Change{c}); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// NextID возвращает номер следующего изменения истории changes.
+func NextID(changes []Change) int {
+	id := 0
+	for _, c := range changes {
+		id = max(id, c.ID)
+	}
+	return id + 1
+}
+
+// LastUndoable возвращает последнее изменение, которое ещё не отменено.
+// Отмены сами не отменяются.
+func LastUndoable(changes []Change) (Change, bool) {
+	undone := make(map[int]bool)
+	for _, c := range changes {
+		if c.Action == ActionUndo {
+			undone[c.Undoes] = true
+		}
+	}
+	for i := len(changes) - 1; i >= 0; i-- {
+		if c := changes[i]; c.Action != ActionUndo && !undone[c.ID] {
+			return c, true
+		}
+	}
+	return Change{}, false
+}
+
+// Revert возвращает изменение автора author, отменяющее c.
+// Номер и время изменения заполняет вызывающий.
+func (c Change) Revert(author string) Change {
+	return Change{
+		Author: author,
+		Action: ActionUndo,
+		Before: c.After,
+		After:  c.Before,
+		Undoes: c.ID,
+	}
+}
+
+// Apply возвращает записи entries с изменением c: запись Before заменяется
+// на After, удаляется при пустой After и добавляется при пустой Before.
+// Если записи Before в журнале нет, возвращает ошибку.
+func (c Change) Apply(entries []journal.Entry) ([]journal.Entry, error) {
+	res := make([]journal.Entry, 0, len(entries)+1)
+	found := isZero(c.Before)
+	for _, e := range entries {
+		if !found && same(e, c.Before) {
+			found = true
+			if !isZero(c.After) {
+				res = append(res, c.After)
+			}
+			continue
+		}
+		res = append(res, e)
+	}
+	if !found {
+		return nil, parseerr.New("record", parseerr.KindConflict, "в журнале нет записи %s %s %q: журнал изменён после правки",
+			c.Before.Time.Format(timeLayout), c.Before.Kind, c.Before.Record)
+	}
+	if isZero(c.Before) {
+		res = append(res, c.After)
+	}
+	return res, nil
+}
@@ -0,0 +1,103 @@
+package audit
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/journal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditTestSuite struct {
+	suite.Suite
+}
+
+func TestAuditSuite(t *testing.T) {
+	suite.Run(t, new(AuditTestSuite))
+}
+
+var (
+	morning = time.Date(2026, 10, 12, 8, 0, 0, 0, time.UTC)
+	evening = time.Date(2026, 10, 12, 18, 0, 0, 0, time.UTC)
+	typo    = journal.Entry{Time: morning, Kind: journal.KindSteps, Record: "78920,1h00m"}
+	fixed   = journal.Entry{Time: morning, Kind: journal.KindSteps, Record: "7892,1h00m"}
+	run     = journal.Entry{Time: evening, Kind: journal.KindTraining, Record: "6000,Бег,1h00m"}
+)
+
+func (suite *AuditTestSuite) TestApplyAndRevert() {
+	entries := []journal.Entry{typo, run}
+
+	edit := Change{ID: 1, Action: ActionEdit, Before: typo, After: fixed}
+	got, err := edit.Apply(entries)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), []journal.Entry{fixed, run}, got)
+
+	del := Change{ID: 2, Action: ActionDelete, Before: run}
+	got, err = del.Apply(got)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), []journal.Entry{fixed}, got)
+
+	undo := del.Revert("anna")
+	assert.Equal(suite.T(), Change{Author: "anna", Action: ActionUndo, After: run, Undoes: 2}, undo)
+	got, err = undo.Apply(got)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), []journal.Entry{fixed, run}, got)
+
+	// Повторное удаление уже удалённой записи невозможно.
+	_, err = del.Apply([]journal.Entry{fixed})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *AuditTestSuite) TestLastUndoable() {
+	_, ok := LastUndoable(nil)
+	assert.False(suite.T(), ok)
+
+	changes := []Change{
+		{ID: 1, Action: ActionEdit},
+		{ID: 2, Action: ActionDelete},
+		{ID: 3, Action: ActionUndo, Undoes: 2},
+	}
+	got, ok := LastUndoable(changes)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), 1, got.ID)
+	assert.Equal(suite.T(), 4, NextID(changes))
+
+	_, ok = LastUndoable(append(changes, Change{ID: 4, Action: ActionUndo, Undoes: 1}))
+	assert.False(suite.T(), ok)
+}
+
+func (suite *AuditTestSuite) TestLoadAppendText() {
+	path := filepath.Join(suite.T().TempDir(), "audit.jsonl")
+	got, err := Load(path)
+	suite.Require().NoError(err)
+	assert.Empty(suite.T(), got)
+
+	at := time.Date(2026, 10, 19, 10, 15, 0, 0, time.UTC)
+	changes := []Change{
+		{ID: 1, Time: at, Author: "anna", Action: ActionEdit, Before: typo, After: fixed},
+		{ID: 2, Time: at, Action: ActionDelete, Before: run},
+		{ID: 3, Time: at, Author: "anna", Action: ActionUndo, After: run, Undoes: 2},
+	}
+	for _, c := range changes {
+		suite.Require().NoError(Append(path, c))
+	}
+
+	got, err = Load(path)
+	suite.Require().NoError(err)
+	suite.Require().Len(got, len(changes))
+	for i := range changes {
+		assert.Equal(suite.T(), changes[i].ID, got[i].ID)
+		assert.True(suite.T(), same(changes[i].Before, got[i].Before))
+		assert.True(suite.T(), same(changes[i].After, got[i].After))
+		assert.Equal(suite.T(), changes[i].Undoes, got[i].Undoes)
+	}
+
+	var buf bytes.Buffer
+	suite.Require().NoError(WriteText(&buf, got))
+	assert.Equal(suite.T(), "#1 19.10.2026 10:15 anna: edit 12.10.2026 08:00 steps 78920,1h00m → 7892,1h00m\n"+
+		"#2 19.10.2026 10:15 неизвестно: delete 12.10.2026 18:00 training 6000,Бег,1h00m → —\n"+
+		"#3 19.10.2026 10:15 anna: undo #2 12.10.2026 18:00 training — → 6000,Бег,1h00m\n", buf.String())
+}
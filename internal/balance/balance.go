@@ -0,0 +1,213 @@
+package balance
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/body"
+	"github.com/Yandex-Practicum/tracker/internal/nutrition"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/Yandex-Practicum/tracker/internal/zone"
+)
+
+const (
+	// kcalPerKg — дефицит или избыток энергии, примерно равный килограмму массы тела, ккал.
+	kcalPerKg = 7700
+	// dateLayout — формат даты в отчёте.
+	dateLayout = "02.01.2006"
+)
+
+// ErrNoBMR возвращается, если в профиле нет данных для расчёта основного обмена.
+var ErrNoBMR = errors.New("в профиле не заданы пол и год рождения, нужные для расчёта основного обмена")
+
+// Day — энергетический баланс дня.
+type Day struct {
+	Date time.Time
+	// Logged — за день записан хотя бы один приём пищи. Дни без записей
+	// не учитываются в средних и итогах: их баланс неизвестен.
+	Logged   bool
+	Intake   float64 // калорийность приёмов пищи, ккал.
+	BMR      float64 // основной обмен, ккал.
+	Activity float64 // расход на дневную активность и тренировки, ккал.
+}
+
+// Balance возвращает баланс дня: съеденное минус основной обмен и активность, ккал.
+// Отрицательный баланс — дефицит, положительный — избыток.
+func (d Day) Balance() float64 {
+	return d.Intake - d.BMR - d.Activity
+}
+
+// Week — средние за день показатели недели по дням с записями о питании.
+type Week struct {
+	From time.Time // первый день недели в отчёте: понедельник или начало периода.
+	Days int       // дни с записями о питании.
+
+	Intake   float64 // ккал.
+	BMR      float64 // ккал.
+	Activity float64 // ккал.
+	// Trend — значение линии тренда среднего баланса для недели, ккал.
+	Trend float64
+}
+
+// Balance возвращает средний дневной баланс недели, ккал.
+func (w Week) Balance() float64 {
+	return w.Intake - w.BMR - w.Activity
+}
+
+// Report — энергетический баланс за период [From, To) по дням и неделям.
+type Report struct {
+	From, To time.Time
+	Days     []Day
+	Weeks    []Week
+	// Slope — наклон линии тренда: на сколько за неделю меняется средний
+	// дневной баланс, ккал. Линия проводится методом наименьших квадратов
+	// по неделям с записями о питании; меньше двух таких недель — 0.
+	Slope float64
+}
+
+// Compute рассчитывает баланс по отчёту r. Основной обмен каждого дня
+// считается по формуле Миффлина — Сан Жеора с весом и ростом из истории
+// измерений h на этот день, а без измерений — из профиля p. Активность —
+// калории пакетов дневной активности и тренировок дня. Если в профиле
+// не заданы пол или год рождения, возвращает ErrNoBMR.
+func Compute(r report.Report, p profile.Profile, h body.History) (Report, error) {
+	if p.Sex == "" || p.BirthYear == 0 {
+		return Report{}, ErrNoBMR
+	}
+
+	res := Report{From: r.From, To: r.To}
+	for _, d := range r.Days {
+		cur := h.At(d.Date, p)
+		bmr, err := nutrition.BMR(cur.Weight, cur.Height, p.Age(d.Date), p.Sex)
+		if err != nil {
+			return Report{}, fmt.Errorf("%s: %w", d.Date.Format(dateLayout), err)
+		}
+
+		day := Day{Date: d.Date, Logged: len(d.Meals) > 0, Intake: d.Intake(), BMR: bmr, Activity: d.Calories}
+		for _, tr := range d.Trainings {
+			day.Activity += tr.Result.Calories
+		}
+		res.Days = append(res.Days, day)
+	}
+
+	res.Weeks = weeks(res.Days)
+	res.Slope = trend(res.Weeks)
+	return res, nil
+}
+
+// weeks группирует дни по неделям с понедельника и возвращает средние
+// показатели каждой недели по дням с записями о питании.
+func weeks(days []Day) []Week {
+	var res []Week
+	for _, d := range days {
+		if n := len(res); n == 0 || !zone.WeekStart(res[n-1].From).Equal(zone.WeekStart(d.Date)) {
+			res = append(res, Week{From: d.Date})
+		}
+		if !d.Logged {
+			continue
+		}
+		w := &res[len(res)-1]
+		w.Days++
+		w.Intake += d.Intake
+		w.BMR += d.BMR
+		w.Activity += d.Activity
+	}
+	for i := range res {
+		if n := float64(res[i].Days); n > 0 {
+			res[i].Intake /= n
+			res[i].BMR /= n
+			res[i].Activity /= n
+		}
+	}
+	return res
+}
+
+// trend проводит через средние балансы недель с записями прямую методом
+// наименьших квадратов, записывает её значения в Trend каждой недели
+// и возвращает наклон — изменение за неделю, ккал.
+func trend(weeks []Week) float64 {
+	var n, sumX, sumY, sumXY, sumXX float64
+	for i, w := range weeks {
+		if w.Days == 0 {
+			continue
+		}
+		x, y := float64(i), w.Balance()
+		n++
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	if n == 0 {
+		return 0
+	}
+
+	var slope float64
+	if d := n*sumXX - sumX*sumX; n > 1 && d != 0 {
+		slope = (n*sumXY - sumX*sumY) / d
+	}
+	intercept := (sumY - slope*sumX) / n
+	for i := range weeks {
+		weeks[i].Trend = intercept + slope*float64(i)
+	}
+	return slope
+}
+
+// Total возвращает суммарный баланс дней с записями о питании, ккал.
+func (r Report) Total() float64 {
+	var total float64
+	for _, d := range r.Days {
+		if d.Logged {
+			total += d.Balance()
+		}
+	}
+	return total
+}
+
+// WeightChange возвращает оценку изменения массы тела по суммарному балансу, кг.
+func (r Report) WeightChange() float64 {
+	return r.Total() / kcalPerKg
+}
+
+// Text возвращает отчёт: таблицу по дням, средние по неделям с линией тренда и итог.
+func (r Report) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Энергетический баланс за %s — %s\n", r.From.Format(dateLayout), r.To.Add(-time.Nanosecond).Format(dateLayout))
+
+	fmt.Fprintf(&b, "%-10s %8s %8s %10s %8s\n", "Дата", "Съедено", "Обмен", "Активность", "Баланс")
+	for _, d := range r.Days {
+		if !d.Logged {
+			fmt.Fprintf(&b, "%-10s %8s %8.0f %10.0f %8s\n", d.Date.Format(dateLayout), "—", d.BMR, d.Activity, "—")
+			continue
+		}
+		fmt.Fprintf(&b, "%-10s %8.0f %8.0f %10.0f %8.0f\n", d.Date.Format(dateLayout), d.Intake, d.BMR, d.Activity, round(d.Balance(), 0))
+	}
+
+	b.WriteString("\nСредние за день по неделям\n")
+	fmt.Fprintf(&b, "%-10s %4s %8s %8s %10s %8s %8s\n", "Неделя", "Дней", "Съедено", "Обмен", "Активность", "Баланс", "Тренд")
+	for _, w := range r.Weeks {
+		if w.Days == 0 {
+			fmt.Fprintf(&b, "%-10s %4d %8s %8s %10s %8s %8.0f\n", w.From.Format(dateLayout), 0, "—", "—", "—", "—", round(w.Trend, 0))
+			continue
+		}
+		fmt.Fprintf(&b, "%-10s %4d %8.0f %8.0f %10.0f %8.0f %8.0f\n",
+			w.From.Format(dateLayout), w.Days, w.Intake, w.BMR, w.Activity, round(w.Balance(), 0), round(w.Trend, 0))
+	}
+	fmt.Fprintf(&b, "Тренд: средний баланс меняется на %+.0f ккал в день за неделю\n", round(r.Slope, 0))
+	fmt.Fprintf(&b, "Итого: баланс %+.0f ккал, изменение веса около %+.2f кг\n", round(r.Total(), 0), round(r.WeightChange(), 2))
+	return b.String()
+}
+
+// round округляет v до digits знаков после запятой так, чтобы не печатать "-0".
+func round(v float64, digits int) float64 {
+	scale := math.Pow(10, float64(digits))
+	v = math.Round(v*scale) / scale
+	if v == 0 {
+		return 0
+	}
+	return v
+}
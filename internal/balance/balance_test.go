@@ -0,0 +1,94 @@
+package balance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/body"
+	"github.com/Yandex-Practicum/tracker/internal/nutrition"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type BalanceTestSuite struct {
+	suite.Suite
+}
+
+func TestBalanceSuite(t *testing.T) {
+	suite.Run(t, new(BalanceTestSuite))
+}
+
+func date(d int) time.Time {
+	return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC)
+}
+
+// period возвращает отчёт с 11.10.2026 (воскресенье) по 20.10.2026 включительно.
+// В дни intake записан один приём пищи с указанной калорийностью.
+func period(intake map[int]float64) report.Report {
+	r := report.Report{From: date(11), To: date(21)}
+	for d := 11; d <= 20; d++ {
+		day := report.Day{Date: date(d), Calories: 250}
+		if kcal, ok := intake[d]; ok {
+			day.Meals = []nutrition.Meal{{Name: "Обед", Calories: kcal}}
+		}
+		r.Days = append(r.Days, day)
+	}
+	return r
+}
+
+func (suite *BalanceTestSuite) TestCompute() {
+	r := period(map[int]float64{12: 2500, 13: 2000, 19: 1800, 20: 1500})
+	r.Days[8].Trainings = []report.Training{{Result: spentcalories.SessionResult{Calories: 300}}}
+	p := profile.Profile{Weight: 90, Height: 1.8, Sex: profile.SexMale, BirthYear: 1990}
+	h := body.History{{Date: date(12), Weight: 80}, {Date: date(19), Weight: 70}}
+
+	got, err := Compute(r, p, h)
+	suite.Require().NoError(err)
+	suite.Require().Len(got.Days, 10)
+
+	// 80 кг, 1,8 м, 36 лет: 800 + 1125 − 180 + 5 = 1750 ккал.
+	assert.Equal(suite.T(), Day{Date: date(12), Logged: true, Intake: 2500, BMR: 1750, Activity: 250}, got.Days[1])
+	assert.InDelta(suite.T(), 500, got.Days[1].Balance(), 1e-9)
+	assert.True(suite.T(), got.Days[2].Logged)
+	assert.False(suite.T(), got.Days[3].Logged)
+	assert.InDelta(suite.T(), 550, got.Days[8].Activity, 1e-9, "активность — шаги и тренировки")
+	assert.InDelta(suite.T(), 1650, got.Days[8].BMR, 1e-9, "вес берётся из истории измерений на день")
+
+	suite.Require().Len(got.Weeks, 3)
+	assert.Equal(suite.T(), []time.Time{date(11), date(12), date(19)}, []time.Time{got.Weeks[0].From, got.Weeks[1].From, got.Weeks[2].From})
+	assert.Zero(suite.T(), got.Weeks[0].Days)
+	assert.Equal(suite.T(), 2, got.Weeks[1].Days, "дни без записей о питании не входят в средние")
+	assert.InDelta(suite.T(), 250, got.Weeks[1].Balance(), 1e-9)
+	assert.InDelta(suite.T(), -400, got.Weeks[2].Balance(), 1e-9)
+
+	// Неделя без записей не влияет на линию тренда, но получает её значение.
+	assert.InDelta(suite.T(), -650, got.Slope, 1e-9)
+	assert.InDelta(suite.T(), 900, got.Weeks[0].Trend, 1e-9)
+	assert.InDelta(suite.T(), -400, got.Weeks[2].Trend, 1e-9)
+
+	assert.InDelta(suite.T(), -300, got.Total(), 1e-9)
+	assert.InDelta(suite.T(), -300.0/7700, got.WeightChange(), 1e-9)
+
+	text := got.Text()
+	assert.Contains(suite.T(), text, "Энергетический баланс за 11.10.2026 — 20.10.2026\n")
+	assert.Contains(suite.T(), text, "11.10.2026        —     1750        250        —\n")
+	assert.Contains(suite.T(), text, "12.10.2026     2500     1750        250      500\n")
+	assert.Contains(suite.T(), text, "19.10.2026    2     1650     1650        400     -400     -400\n")
+	assert.Contains(suite.T(), text, "Тренд: средний баланс меняется на -650 ккал в день за неделю\n")
+	assert.Contains(suite.T(), text, "Итого: баланс -300 ккал, изменение веса около -0.04 кг\n")
+}
+
+func (suite *BalanceTestSuite) TestSingleWeek() {
+	got, err := Compute(period(map[int]float64{12: 2500}), profile.Profile{Weight: 80, Height: 1.8, Sex: profile.SexFemale, BirthYear: 1990}, nil)
+	suite.Require().NoError(err)
+	assert.Zero(suite.T(), got.Slope, "по одной неделе наклон не определён")
+	assert.InDelta(suite.T(), got.Weeks[1].Balance(), got.Weeks[2].Trend, 1e-9)
+}
+
+func (suite *BalanceTestSuite) TestNoBMR() {
+	_, err := Compute(period(nil), profile.Profile{Weight: 80, Height: 1.8}, nil)
+	assert.ErrorIs(suite.T(), err, ErrNoBMR)
+}
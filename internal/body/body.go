@@ -0,0 +1,238 @@
+package body
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/parseerr"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/zone"
+)
+
+const (
+	// dateLayout — формат даты измерения в файле.
+	dateLayout = "2006-01-02"
+	// fieldSeparator разделяет поля строки файла.
+	fieldSeparator = "\t"
+	// missing обозначает в файле неизмеренный показатель.
+	missing = "-"
+)
+
+// Measurement — измерение тела за день. Нулевой вес или рост означает,
+// что показатель в этот день не измерялся.
+type Measurement struct {
+	Date   time.Time // начало дня измерения в часовом поясе пользователя.
+	Weight float64   // кг.
+	Height float64   // м.
+}
+
+// validate проверяет, что измерен хотя бы один показатель и оба не отрицательны.
+func (m Measurement) validate() error {
+	switch {
+	case m.Weight < 0:
+		return parseerr.New("weight", parseerr.KindRange, "вес не может быть отрицательным: %.2f", m.Weight)
+	case m.Height < 0:
+		return parseerr.New("height", parseerr.KindRange, "рост не может быть отрицательным: %.2f", m.Height)
+	case m.Weight == 0 && m.Height == 0:
+		return parseerr.New("", parseerr.KindFormat, "измерение должно содержать вес или рост")
+	}
+	return nil
+}
+
+// History — измерения, упорядоченные по дате, не больше одного на день.
+type History []Measurement
+
+// Add возвращает историю с измерением m. Измерение за тот же день
+// дополняется: измеренные в m показатели заменяют прежние.
+func (h History) Add(m Measurement) (History, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	m.Date = zone.StartOfDay(m.Date, m.Date.Location())
+
+	res := make(History, 0, len(h)+1)
+	res = append(res, h...)
+	i := sort.Search(len(res), func(i int) bool { return !res[i].Date.Before(m.Date) })
+	if i < len(res) && res[i].Date.Equal(m.Date) {
+		if m.Weight > 0 {
+			res[i].Weight = m.Weight
+		}
+		if m.Height > 0 {
+			res[i].Height = m.Height
+		}
+		return res, nil
+	}
+	return append(res[:i], append(History{m}, res[i:]...)...), nil
+}
+
+// At возвращает профиль p с весом и ростом, действовавшими в момент t:
+// по последнему измерению не позже t. До первого измерения показателя
+// используется его первое измерение, без измерений — значение из p.
+func (h History) At(t time.Time, p profile.Profile) profile.Profile {
+	var weight, height float64
+	for _, m := range h {
+		after := m.Date.After(t)
+		if m.Weight > 0 && (!after || weight == 0) {
+			weight = m.Weight
+		}
+		if m.Height > 0 && (!after || height == 0) {
+			height = m.Height
+		}
+		if after && weight > 0 && height > 0 {
+			break
+		}
+	}
+	if weight > 0 {
+		p.Weight = weight
+	}
+	if height > 0 {
+		p.Height = height
+	}
+	return p
+}
+
+// Latest сообщает, что измерений за дни после date нет.
+func (h History) Latest(date time.Time) bool {
+	return len(h) == 0 || !h[len(h)-1].Date.After(date)
+}
+
+// Read читает измерения в текстовом формате: по измерению на строку,
+// поля "дата 2006-01-02<TAB>вес<TAB>рост", "-" — показатель не измерялся.
+// Измерение действует с начала своего дня в часовом поясе пользователя loc.
+func Read(r io.Reader, loc *time.Location) (History, error) {
+	var h History
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if strings.TrimSpace(text) == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		m, err := parseLine(text, loc)
+		if err != nil {
+			return nil, fmt.Errorf("строка %d: %w", line, err)
+		}
+		if h, err = h.Add(m); err != nil {
+			return nil, fmt.Errorf("строка %d: %w", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось прочитать историю измерений: %w", err)
+	}
+
+	return h, nil
+}
+
+// parseLine разбирает строку с измерением, относя его дату к поясу loc.
+func parseLine(text string, loc *time.Location) (Measurement, error) {
+	parts := strings.Split(text, fieldSeparator)
+	if len(parts) != 3 {
+		return Measurement{}, parseerr.New("", parseerr.KindFormat, "неверный формат измерения: ожидается \"дата<TAB>вес<TAB>рост\"")
+	}
+
+	date, err := time.Parse(dateLayout, parts[0])
+	if err != nil {
+		return Measurement{}, parseerr.New("date", parseerr.KindSyntax, "некорректная дата: %w", err)
+	}
+	y, mo, d := date.Date()
+	date = zone.Date(y, mo, d, loc)
+	weight, err := parseValue(parts[1])
+	if err != nil {
+		return Measurement{}, parseerr.New("weight", parseerr.KindSyntax, "некорректный вес: %w", err)
+	}
+	height, err := parseValue(parts[2])
+	if err != nil {
+		return Measurement{}, parseerr.New("height", parseerr.KindSyntax, "некорректный рост: %w", err)
+	}
+
+	m := Measurement{Date: date, Weight: weight, Height: height}
+	return m, m.validate()
+}
+
+// parseValue разбирает показатель; "-" означает, что он не измерялся.
+func parseValue(s string) (float64, error) {
+	if s == missing {
+		return 0, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// formatValue форматирует показатель для записи в файл.
+func formatValue(v float64) string {
+	if v == 0 {
+		return missing
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// Write записывает измерения в текстовом формате, который читает Read.
+func Write(w io.Writer, h History) error {
+	bw := bufio.NewWriter(w)
+	for _, m := range h {
+		fmt.Fprintf(bw, "%s%s%s%s%s\n", m.Date.Format(dateLayout), fieldSeparator, formatValue(m.Weight), fieldSeparator, formatValue(m.Height))
+	}
+	return bw.Flush()
+}
+
+// Load читает историю измерений из файла, относя даты к поясу пользователя loc.
+// Отсутствующий файл считается пустой историей.
+func Load(path string, loc *time.Location) (History, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось открыть историю измерений: %w", err)
+	}
+	defer f.Close()
+
+	h, err := Read(f, loc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return h, nil
+}
+
+// Save перезаписывает файл истории измерений.
+func Save(path string, h History) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("не удалось сохранить историю измерений: %w", err)
+	}
+	if err := Write(f, h); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("не удалось сохранить историю измерений: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// Text возвращает историю измерений в текстовом виде.
+func (h History) Text() string {
+	var b strings.Builder
+	for _, m := range h {
+		b.WriteString(m.Date.Format("02.01.2006") + ":")
+		if m.Weight > 0 {
+			fmt.Fprintf(&b, " вес %.1f кг", m.Weight)
+		}
+		if m.Height > 0 {
+			fmt.Fprintf(&b, " рост %.2f м", m.Height)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
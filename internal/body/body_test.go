@@ -0,0 +1,109 @@
+package body
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type BodyTestSuite struct {
+	suite.Suite
+}
+
+func TestBodySuite(t *testing.T) {
+	suite.Run(t, new(BodyTestSuite))
+}
+
+func day(d int) time.Time {
+	return time.Date(2026, 10, d, 0, 0, 0, 0, time.Local)
+}
+
+func (suite *BodyTestSuite) TestAdd() {
+	var h History
+	var err error
+	for _, m := range []Measurement{
+		{Date: day(10), Weight: 84},
+		{Date: day(1), Weight: 86, Height: 1.8},
+		{Date: day(10).Add(20 * time.Hour), Height: 1.81},
+	} {
+		h, err = h.Add(m)
+		suite.Require().NoError(err)
+	}
+	assert.Equal(suite.T(), History{
+		{Date: day(1), Weight: 86, Height: 1.8},
+		{Date: day(10), Weight: 84, Height: 1.81},
+	}, h)
+
+	_, err = h.Add(Measurement{Date: day(2)})
+	assert.Error(suite.T(), err)
+	_, err = h.Add(Measurement{Date: day(2), Weight: -1})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *BodyTestSuite) TestAt() {
+	h := History{
+		{Date: day(5), Weight: 86},
+		{Date: day(10), Weight: 84, Height: 1.8},
+		{Date: day(20), Weight: 82},
+	}
+	p := profile.Profile{Weight: 90, Height: 1.75, StepGoal: 8000}
+
+	assert.Equal(suite.T(), profile.Profile{Weight: 86, Height: 1.8, StepGoal: 8000}, h.At(day(1), p), "до первых измерений — первые измерения")
+	assert.Equal(suite.T(), 86.0, h.At(day(9).Add(23*time.Hour), p).Weight)
+	assert.Equal(suite.T(), 84.0, h.At(day(10), p).Weight)
+	assert.Equal(suite.T(), profile.Profile{Weight: 82, Height: 1.8, StepGoal: 8000}, h.At(day(25), p))
+	assert.Equal(suite.T(), p, History(nil).At(day(1), p))
+}
+
+func (suite *BodyTestSuite) TestReadWrite() {
+	h := History{
+		{Date: day(5), Weight: 86.5},
+		{Date: day(10), Weight: 84, Height: 1.8},
+	}
+	var buf bytes.Buffer
+	suite.Require().NoError(Write(&buf, h))
+	assert.Equal(suite.T(), "2026-10-05\t86.5\t-\n2026-10-10\t84\t1.8\n", buf.String())
+
+	got, err := Read(&buf, time.Local)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), h, got)
+
+	_, err = Read(bytes.NewBufferString("2026-10-05\t-\t-\n"), time.Local)
+	assert.Error(suite.T(), err)
+	_, err = Read(bytes.NewBufferString("2026-10-05\t80\n"), time.Local)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *BodyTestSuite) TestReadLocation() {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	suite.Require().NoError(err)
+
+	h, err := Read(bytes.NewBufferString("2026-10-05\t86\t-\n2026-10-10\t84\t1.8\n"), tokyo)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), time.Date(2026, 10, 9, 15, 0, 0, 0, time.UTC), h[1].Date.UTC(), "день измерения начинается в полночь по поясу пользователя")
+
+	// 10 октября в Токио начинается, когда в Европе и Америке ещё 9 октября.
+	p := profile.Profile{Weight: 90, Height: 1.75}
+	assert.Equal(suite.T(), 86.0, h.At(time.Date(2026, 10, 9, 23, 30, 0, 0, tokyo), p).Weight)
+	assert.Equal(suite.T(), 84.0, h.At(time.Date(2026, 10, 10, 0, 30, 0, 0, tokyo), p).Weight)
+}
+
+func (suite *BodyTestSuite) TestLoadSave() {
+	path := filepath.Join(suite.T().TempDir(), "body.txt")
+	h, err := Load(path, time.Local)
+	suite.Require().NoError(err)
+	assert.Empty(suite.T(), h)
+
+	h, err = h.Add(Measurement{Date: day(5), Weight: 80})
+	suite.Require().NoError(err)
+	suite.Require().NoError(Save(path, h))
+	got, err := Load(path, time.Local)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), h, got)
+	assert.Equal(suite.T(), "05.10.2026: вес 80.0 кг\n", got.Text())
+}
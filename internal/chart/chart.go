@@ -0,0 +1,134 @@
+package chart
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/Yandex-Practicum/tracker/internal/report"
+)
+
+// Kind — вид диаграммы.
+type Kind string
+
+// Виды диаграмм.
+const (
+	KindSteps    Kind = "steps"    // шаги по дням.
+	KindCalories Kind = "calories" // калории по типам тренировок.
+	KindPace     Kind = "pace"     // темп беговых тренировок.
+)
+
+// Format — формат изображения.
+type Format string
+
+// Форматы изображений.
+const (
+	FormatSVG Format = "svg"
+	FormatPNG Format = "png"
+)
+
+// Размеры изображения в пикселях.
+const (
+	width  = 640
+	height = 320
+	margin = 40
+)
+
+// Series — данные диаграммы: подписи и значения по оси X.
+type Series struct {
+	Title  string
+	Unit   string
+	Labels []string
+	Values []float64
+	// Line — рисовать ломаную вместо столбцов.
+	Line bool
+}
+
+// FromReport возвращает данные диаграммы вида kind по отчёту.
+func FromReport(r report.Report, kind Kind) (Series, error) {
+	switch kind {
+	case KindSteps:
+		return steps(r), nil
+	case KindCalories:
+		return caloriesByType(r), nil
+	case KindPace:
+		return pace(r), nil
+	default:
+		return Series{}, fmt.Errorf("неизвестный вид диаграммы: %q", kind)
+	}
+}
+
+// Render записывает диаграмму s в формате f.
+func Render(w io.Writer, s Series, f Format) error {
+	switch f {
+	case FormatSVG:
+		return SVG(w, s)
+	case FormatPNG:
+		return PNG(w, s)
+	default:
+		return fmt.Errorf("неизвестный формат изображения: %q", f)
+	}
+}
+
+func steps(r report.Report) Series {
+	s := Series{Title: "Шаги по дням", Unit: "шагов"}
+	for _, d := range r.Days {
+		s.Labels = append(s.Labels, d.Date.Format("02.01"))
+		s.Values = append(s.Values, float64(d.Steps))
+	}
+	return s
+}
+
+func caloriesByType(r report.Report) Series {
+	totals := make(map[string]float64)
+	for _, d := range r.Days {
+		for _, tr := range d.Trainings {
+			totals[tr.Result.Type()] += tr.Result.Calories
+		}
+	}
+
+	s := Series{Title: "Калории по типам тренировок", Unit: "ккал"}
+	for t := range totals {
+		s.Labels = append(s.Labels, t)
+	}
+	sort.Strings(s.Labels)
+	for _, t := range s.Labels {
+		s.Values = append(s.Values, totals[t])
+	}
+	return s
+}
+
+func pace(r report.Report) Series {
+	s := Series{Title: "Темп беговых тренировок", Unit: "мин/км", Line: true}
+	for _, d := range r.Days {
+		for _, tr := range d.Trainings {
+			p, ok := tr.Pace()
+			if !ok {
+				continue
+			}
+			s.Labels = append(s.Labels, tr.Time.Format("02.01"))
+			s.Values = append(s.Values, p.PerKm.Minutes())
+		}
+	}
+	return s
+}
+
+// maxValue возвращает наибольшее значение ряда, но не меньше единицы.
+func maxValue(values []float64) float64 {
+	m := 1.0
+	for _, v := range values {
+		m = max(m, v)
+	}
+	return m
+}
+
+// point возвращает координаты i-го значения v из n на площадке диаграммы.
+// Для столбцов это левый верхний угол столбца шириной slot.
+func point(i, n int, v, top float64) (x, y, slot float64) {
+	plotW := float64(width - 2*margin)
+	plotH := float64(height - 2*margin)
+	slot = plotW / float64(max(n, 1))
+	x = float64(margin) + slot*float64(i)
+	y = float64(height-margin) - v/top*plotH
+	return x, y, slot
+}
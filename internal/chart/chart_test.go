@@ -0,0 +1,111 @@
+package chart
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ChartTestSuite struct {
+	suite.Suite
+}
+
+func TestChartSuite(t *testing.T) {
+	suite.Run(t, new(ChartTestSuite))
+}
+
+// sample возвращает отчёт за два дня с тремя тренировками.
+func (suite *ChartTestSuite) sample() report.Report {
+	training := func(day int, record string) report.Training {
+		res, err := spentcalories.Summarize(record, 75, 1.75)
+		suite.Require().NoError(err)
+		return report.Training{Time: time.Date(2026, 10, day, 18, 0, 0, 0, time.UTC), Record: record, Result: res}
+	}
+
+	return report.Report{Days: []report.Day{
+		{
+			Date:      time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC),
+			Steps:     8000,
+			Trainings: []report.Training{training(12, "6000,Бег,1h00m"), training(12, "6000,Ходьба,1h00m")},
+		},
+		{
+			Date:      time.Date(2026, 10, 13, 0, 0, 0, 0, time.UTC),
+			Steps:     12000,
+			Trainings: []report.Training{training(13, "6000,Бег,30m")},
+		},
+	}}
+}
+
+func (suite *ChartTestSuite) TestFromReport() {
+	r := suite.sample()
+
+	s, err := FromReport(r, KindSteps)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), []string{"12.10", "13.10"}, s.Labels)
+	assert.Equal(suite.T(), []float64{8000, 12000}, s.Values)
+
+	s, err = FromReport(r, KindCalories)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), []string{"Бег", "Ходьба"}, s.Labels)
+	assert.InDelta(suite.T(), 354.375*2, s.Values[0], 0.001)
+	assert.InDelta(suite.T(), 177.1875, s.Values[1], 0.001)
+
+	s, err = FromReport(r, KindPace)
+	suite.Require().NoError(err)
+	assert.True(suite.T(), s.Line)
+	suite.Require().Len(s.Values, 2)
+	assert.InDelta(suite.T(), 60/4.725, s.Values[0], 0.001)
+	assert.InDelta(suite.T(), 30/4.725, s.Values[1], 0.001)
+
+	_, err = FromReport(r, "weight")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ChartTestSuite) TestSVG() {
+	s, _ := FromReport(suite.sample(), KindSteps)
+	s.Title = "Шаги <и> дни"
+
+	var buf bytes.Buffer
+	suite.Require().NoError(Render(&buf, s, FormatSVG))
+
+	got := buf.String()
+	assert.True(suite.T(), strings.HasPrefix(got, "<svg "))
+	assert.Contains(suite.T(), got, "Шаги &lt;и&gt; дни")
+	assert.Equal(suite.T(), 2, strings.Count(got, `fill="steelblue"`))
+	assert.Contains(suite.T(), got, ">12000</text>")
+}
+
+func (suite *ChartTestSuite) TestPNG() {
+	for _, kind := range []Kind{KindSteps, KindPace} {
+		s, _ := FromReport(suite.sample(), kind)
+
+		var buf bytes.Buffer
+		suite.Require().NoError(Render(&buf, s, FormatPNG))
+
+		img, err := png.Decode(&buf)
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), width, img.Bounds().Dx())
+		assert.Equal(suite.T(), height, img.Bounds().Dy())
+
+		// Под последним значением должен быть цвет диаграммы.
+		x, y, slot := point(len(s.Values)-1, len(s.Values), s.Values[len(s.Values)-1], maxValue(s.Values))
+		r, g, b, _ := img.At(int(x+slot/2), int(y)+1).RGBA()
+		ar, ag, ab, _ := accent.RGBA()
+		assert.Equal(suite.T(), [3]uint32{ar, ag, ab}, [3]uint32{r, g, b}, string(kind))
+	}
+
+	assert.Error(suite.T(), Render(&bytes.Buffer{}, Series{}, "gif"))
+}
+
+func (suite *ChartTestSuite) TestEmptySeries() {
+	var buf bytes.Buffer
+	assert.NoError(suite.T(), SVG(&buf, Series{Title: "пусто"}))
+	assert.NoError(suite.T(), PNG(&buf, Series{}))
+}
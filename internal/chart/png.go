@@ -0,0 +1,127 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"strconv"
+)
+
+// Цвета изображения PNG.
+var (
+	background = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	foreground = color.RGBA{A: 0xff}
+	accent     = color.RGBA{R: 0x46, G: 0x82, B: 0xb4, A: 0xff}
+)
+
+// digits — растровые цифры 3×5 точек: по строке на три бита.
+var digits = [10][5]uint8{
+	{7, 5, 5, 5, 7}, {2, 6, 2, 2, 7}, {7, 1, 7, 4, 7}, {7, 1, 7, 1, 7}, {5, 5, 7, 1, 1},
+	{7, 4, 7, 1, 7}, {7, 4, 7, 5, 7}, {7, 1, 1, 1, 1}, {7, 5, 7, 5, 7}, {7, 5, 7, 1, 7},
+}
+
+// digitScale — размер точки растровой цифры в пикселях.
+const digitScale = 2
+
+// PNG записывает диаграмму в формате PNG. Стандартная библиотека не умеет
+// рисовать текст, поэтому подписи ограничены числами шкалы.
+func PNG(w io.Writer, s Series) error {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	fill(img, img.Bounds(), background)
+
+	top := maxValue(s.Values)
+	n := len(s.Values)
+
+	line(img, margin, margin, margin, height-margin, foreground)
+	line(img, margin, height-margin, width-margin, height-margin, foreground)
+	number(img, margin-4, margin-2, strconv.FormatFloat(math.Round(top), 'f', 0, 64))
+	number(img, margin-4, height-margin-2, "0")
+
+	prevX, prevY := -1, -1
+	for i, v := range s.Values {
+		x, y, slot := point(i, n, v, top)
+		cx, cy := int(x+slot/2), int(y)
+		if s.Line {
+			fill(img, image.Rect(cx-2, cy-2, cx+3, cy+3), accent)
+			if prevX >= 0 {
+				line(img, prevX, prevY, cx, cy, accent)
+			}
+			prevX, prevY = cx, cy
+		} else {
+			fill(img, image.Rect(int(x+slot*0.1), cy, int(x+slot*0.9), height-margin), accent)
+		}
+	}
+
+	return png.Encode(w, img)
+}
+
+// fill закрашивает прямоугольник r.
+func fill(img *image.RGBA, r image.Rectangle, c color.Color) {
+	r = r.Intersect(img.Bounds())
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			img.Set(x, y, c)
+		}
+	}
+}
+
+// line рисует отрезок алгоритмом Брезенхэма.
+func line(img *image.RGBA, x0, y0, x1, y1 int, c color.Color) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := sign(x1-x0), sign(y1-y0)
+	e := dx + dy
+	for {
+		img.Set(x0, y0, c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+// number рисует число s так, чтобы его правый край был в x, а верх — в y.
+func number(img *image.RGBA, x, y int, s string) {
+	const glyphW = 4 * digitScale // три точки и промежуток.
+	x -= len(s) * glyphW
+	for i, ch := range s {
+		if ch < '0' || ch > '9' {
+			continue
+		}
+		for row, bits := range digits[ch-'0'] {
+			for col := 0; col < 3; col++ {
+				if bits&(4>>col) == 0 {
+					continue
+				}
+				px, py := x+i*glyphW+col*digitScale, y+row*digitScale
+				fill(img, image.Rect(px, py, px+digitScale, py+digitScale), foreground)
+			}
+		}
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func sign(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,51 @@
+package chart
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+)
+
+// SVG записывает диаграмму в формате SVG с подписями осей.
+func SVG(w io.Writer, s Series) error {
+	bw := bufio.NewWriter(w)
+	top := maxValue(s.Values)
+	n := len(s.Values)
+
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="11">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(bw, `<rect width="%d" height="%d" fill="white"/>`+"\n", width, height)
+	fmt.Fprintf(bw, `<text x="%d" y="%d" font-size="14">%s</text>`+"\n", margin, margin/2, html.EscapeString(s.Title))
+
+	// Оси и подписи шкалы.
+	fmt.Fprintf(bw, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`+"\n", margin, margin, margin, height-margin)
+	fmt.Fprintf(bw, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`+"\n", margin, height-margin, width-margin, height-margin)
+	fmt.Fprintf(bw, `<text x="%d" y="%d" text-anchor="end">%.0f</text>`+"\n", margin-4, margin+4, top)
+	fmt.Fprintf(bw, `<text x="%d" y="%d" text-anchor="end">0</text>`+"\n", margin-4, height-margin+4)
+	fmt.Fprintf(bw, `<text x="%d" y="%d" text-anchor="end">%s</text>`+"\n", width-margin, margin-4, html.EscapeString(s.Unit))
+
+	var path string
+	for i, v := range s.Values {
+		x, y, slot := point(i, n, v, top)
+		cx := x + slot/2
+		if s.Line {
+			cmd := "L"
+			if i == 0 {
+				cmd = "M"
+			}
+			path += fmt.Sprintf("%s%.1f %.1f ", cmd, cx, y)
+			fmt.Fprintf(bw, `<circle cx="%.1f" cy="%.1f" r="3" fill="steelblue"><title>%.2f</title></circle>`+"\n", cx, y, v)
+		} else {
+			fmt.Fprintf(bw, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="steelblue"><title>%.2f</title></rect>`+"\n",
+				x+slot*0.1, y, slot*0.8, float64(height-margin)-y, v)
+		}
+		fmt.Fprintf(bw, `<text x="%.1f" y="%d" text-anchor="middle">%s</text>`+"\n", cx, height-margin+14, html.EscapeString(s.Labels[i]))
+	}
+	if path != "" {
+		fmt.Fprintf(bw, `<path d="%s" fill="none" stroke="steelblue" stroke-width="2"/>`+"\n", path)
+	}
+
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
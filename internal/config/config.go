@@ -0,0 +1,104 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Значения коэффициентов по умолчанию.
+const (
+	defaultStepLength                 = 0.65 // средняя длина шага, м.
+	defaultStepLengthCoefficient      = 0.45 // коэффициент для расчета длины шага на основе роста.
+	defaultWalkingCaloriesCoefficient = 0.5  // коэффициент для расчета калорий при ходьбе.
+	defaultRunningPowerEfficiency     = 0.24 // доля затраченной энергии, переходящая в мощность бега.
+)
+
+// Config содержит физиологические коэффициенты, используемые в расчётах.
+type Config struct {
+	// StepLength — средняя длина шага в метрах для дневной активности.
+	StepLength float64 `yaml:"step_length" json:"step_length"`
+	// StepLengthCoefficient — отношение длины шага к росту на тренировках.
+	StepLengthCoefficient float64 `yaml:"step_length_coefficient" json:"step_length_coefficient"`
+	// WalkingCaloriesCoefficient — доля расхода калорий при ходьбе относительно бега.
+	WalkingCaloriesCoefficient float64 `yaml:"walking_calories_coefficient" json:"walking_calories_coefficient"`
+	// RunningPowerEfficiency — КПД бега: отношение работы по данным датчика мощности
+	// к затраченной энергии. Используется для тренировок с мощностью.
+	RunningPowerEfficiency float64 `yaml:"running_power_efficiency" json:"running_power_efficiency"`
+	// SourcePriority — источники шагов по убыванию доверия. На пересечении пакетов
+	// дневной активности учитывается источник, указанный раньше; без списка —
+	// пакет с наибольшей частотой шагов, насчитавший на общем отрезке больше шагов.
+	SourcePriority []string `yaml:"source_priority,omitempty" json:"source_priority,omitempty"`
+}
+
+// Default возвращает конфигурацию с коэффициентами по умолчанию.
+func Default() Config {
+	return Config{
+		StepLength:                 defaultStepLength,
+		StepLengthCoefficient:      defaultStepLengthCoefficient,
+		WalkingCaloriesCoefficient: defaultWalkingCaloriesCoefficient,
+		RunningPowerEfficiency:     defaultRunningPowerEfficiency,
+	}
+}
+
+// Load читает конфигурацию из файла YAML (.yaml, .yml) или JSON (.json).
+// Не указанные в файле коэффициенты сохраняют значения по умолчанию,
+// неизвестные ключи считаются ошибкой.
+func Load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("не удалось прочитать конфигурацию: %w", err)
+	}
+
+	cfg := Default()
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		// Пустой файл YAML допустим и означает значения по умолчанию.
+		if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+			return Config{}, fmt.Errorf("некорректная конфигурация %s: %w", path, err)
+		}
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&cfg); err != nil {
+			return Config{}, fmt.Errorf("некорректная конфигурация %s: %w", path, err)
+		}
+	default:
+		return Config{}, fmt.Errorf("неподдерживаемый формат конфигурации: %q", ext)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// Validate проверяет, что все коэффициенты положительны, КПД не больше единицы,
+// а источники в приоритете названы.
+func (c Config) Validate() error {
+	for _, source := range c.SourcePriority {
+		if strings.TrimSpace(source) == "" {
+			return fmt.Errorf("пустое название источника в приоритете: %q", c.SourcePriority)
+		}
+	}
+	switch {
+	case c.StepLength <= 0:
+		return fmt.Errorf("длина шага должна быть больше нуля: %.2f", c.StepLength)
+	case c.StepLengthCoefficient <= 0:
+		return fmt.Errorf("коэффициент длины шага должен быть больше нуля: %.2f", c.StepLengthCoefficient)
+	case c.WalkingCaloriesCoefficient <= 0:
+		return fmt.Errorf("коэффициент калорий при ходьбе должен быть больше нуля: %.2f", c.WalkingCaloriesCoefficient)
+	case c.RunningPowerEfficiency <= 0 || c.RunningPowerEfficiency > 1:
+		return fmt.Errorf("КПД бега должен быть в диапазоне (0, 1]: %.2f", c.RunningPowerEfficiency)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfigTestSuite struct {
+	suite.Suite
+}
+
+func TestConfigSuite(t *testing.T) {
+	suite.Run(t, new(ConfigTestSuite))
+}
+
+func (suite *ConfigTestSuite) writeFile(name, content string) string {
+	path := filepath.Join(suite.T().TempDir(), name)
+	suite.Require().NoError(os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func (suite *ConfigTestSuite) TestLoad() {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    Config
+		wantErr bool
+	}{
+		{
+			name:    "yaml - все коэффициенты",
+			file:    "config.yaml",
+			content: "step_length: 0.7\nstep_length_coefficient: 0.41\nwalking_calories_coefficient: 0.55\nrunning_power_efficiency: 0.22\n",
+			want:    Config{StepLength: 0.7, StepLengthCoefficient: 0.41, WalkingCaloriesCoefficient: 0.55, RunningPowerEfficiency: 0.22},
+		},
+		{
+			name:    "yml - часть коэффициентов",
+			file:    "config.yml",
+			content: "step_length_coefficient: 0.42\n",
+			want:    Config{StepLength: 0.65, StepLengthCoefficient: 0.42, WalkingCaloriesCoefficient: 0.5, RunningPowerEfficiency: 0.24},
+		},
+		{
+			name:    "yaml - пустой файл",
+			file:    "config.yaml",
+			content: "",
+			want:    Default(),
+		},
+		{
+			name:    "json",
+			file:    "config.json",
+			content: `{"walking_calories_coefficient": 0.6}`,
+			want:    Config{StepLength: 0.65, StepLengthCoefficient: 0.45, WalkingCaloriesCoefficient: 0.6, RunningPowerEfficiency: 0.24},
+		},
+		{
+			name:    "неизвестный ключ",
+			file:    "config.yaml",
+			content: "step_lenght: 0.7\n",
+			wantErr: true,
+		},
+		{
+			name:    "неизвестный ключ json",
+			file:    "config.json",
+			content: `{"step": 0.7}`,
+			wantErr: true,
+		},
+		{
+			name:    "отрицательный коэффициент",
+			file:    "config.yaml",
+			content: "step_length: -0.7\n",
+			wantErr: true,
+		},
+		{
+			name:    "КПД больше единицы",
+			file:    "config.json",
+			content: `{"running_power_efficiency": 1.2}`,
+			wantErr: true,
+		},
+		{
+			name:    "приоритет источников",
+			file:    "config.yaml",
+			content: "source_priority: [watch, phone]\n",
+			want:    Config{StepLength: 0.65, StepLengthCoefficient: 0.45, WalkingCaloriesCoefficient: 0.5, RunningPowerEfficiency: 0.24, SourcePriority: []string{"watch", "phone"}},
+		},
+		{
+			name:    "пустой источник в приоритете",
+			file:    "config.json",
+			content: `{"source_priority": ["watch", ""]}`,
+			wantErr: true,
+		},
+		{
+			name:    "неподдерживаемый формат",
+			file:    "config.toml",
+			content: "step_length = 0.7\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			got, err := Load(suite.writeFile(tt.file, tt.content))
+
+			if tt.wantErr {
+				assert.Error(suite.T(), err)
+				return
+			}
+
+			assert.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tt.want, got)
+		})
+	}
+}
+
+func (suite *ConfigTestSuite) TestLoadMissingFile() {
+	_, err := Load(filepath.Join(suite.T().TempDir(), "missing.yaml"))
+	assert.Error(suite.T(), err)
+}
@@ -0,0 +1,136 @@
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/recovery"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+)
+
+// Управляющие последовательности ANSI.
+const (
+	enterAltScreen = "\x1b[?1049h"
+	leaveAltScreen = "\x1b[?1049l"
+	hideCursor     = "\x1b[?25l"
+	showCursor     = "\x1b[?25h"
+	clearScreen    = "\x1b[H\x1b[2J"
+	bold           = "\x1b[1m"
+	green          = "\x1b[32m"
+	reset          = "\x1b[0m"
+)
+
+// Размеры элементов экрана.
+const (
+	progressWidth = 40 // ширина полосы прогресса в символах.
+	chartHeight   = 8  // высота диаграммы шагов по часам в строках.
+)
+
+// Start переводит терминал в альтернативный экран и скрывает курсор.
+const Start = enterAltScreen + hideCursor
+
+// Stop возвращает курсор и основной экран терминала.
+const Stop = showCursor + leaveAltScreen
+
+// State — данные для отрисовки экрана.
+type State struct {
+	Day     report.Day
+	Goal    int       // дневная цель по шагам.
+	Updated time.Time // момент последнего обновления.
+}
+
+// Render возвращает кадр экрана, начиная с очистки терминала.
+func Render(s State) string {
+	var b strings.Builder
+	b.WriteString(clearScreen)
+
+	fmt.Fprintf(&b, "%sАктивность за %s%s\n\n", bold, s.Day.Date.Format("02.01.2006"), reset)
+	fmt.Fprintf(&b, "Шаги:      %d\n", s.Day.Steps)
+	fmt.Fprintf(&b, "Дистанция: %.2f км\n", s.Day.Distance)
+	fmt.Fprintf(&b, "Калории:   %.2f ккал\n", s.Day.Calories)
+	if len(s.Day.Sleep) > 0 {
+		fmt.Fprintf(&b, "Сон:       %.2f ч.", s.Day.SleepDuration().Hours())
+		if q := s.Day.SleepQuality(); q > 0 {
+			fmt.Fprintf(&b, ", качество %.1f из %d", q, recovery.MaxQuality)
+		}
+		b.WriteString("\n")
+	}
+	if s.Day.Rest != nil {
+		fmt.Fprintf(&b, "Отдых:     %s\n", s.Day.Rest.Note)
+	}
+	b.WriteString("\n")
+
+	b.WriteString(progress(s.Day.Steps, s.Goal))
+	b.WriteString("\n")
+
+	fmt.Fprintf(&b, "%sШаги по часам%s\n", bold, reset)
+	b.WriteString(chart(s.Day.Hourly))
+	b.WriteString("\n")
+
+	fmt.Fprintf(&b, "%sТренировки%s\n", bold, reset)
+	if len(s.Day.Trainings) == 0 {
+		b.WriteString("  нет\n")
+	}
+	for _, tr := range s.Day.Trainings {
+		r := tr.Result
+		fmt.Fprintf(&b, "  %s  %-12s %.2f ч.  %.2f км  %.2f км/ч  %.2f ккал",
+			tr.Time.Format("15:04"), r.Type(), r.Duration.Hours(), r.Distance, r.Speed, r.Calories)
+		if p, ok := tr.Pace(); ok {
+			fmt.Fprintf(&b, "  %s мин/км", p.Clock())
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "\nОбновлено в %s. Выход — Ctrl+C.\n", s.Updated.Format("15:04:05"))
+	return b.String()
+}
+
+// progress возвращает полосу прогресса к цели по шагам.
+func progress(steps, goal int) string {
+	if goal <= 0 {
+		return ""
+	}
+	ratio := float64(steps) / float64(goal)
+	filled := min(int(ratio*progressWidth), progressWidth)
+
+	color := ""
+	if steps >= goal {
+		color = green
+	}
+	return fmt.Sprintf("Цель %d: [%s%s%s%s] %.0f%%\n",
+		goal, color, strings.Repeat("█", filled), reset, strings.Repeat("░", progressWidth-filled), ratio*100)
+}
+
+// chart возвращает столбчатую диаграмму шагов по часам: по два символа на час.
+func chart(hourly [24]int) string {
+	peak := 0
+	for _, v := range hourly {
+		peak = max(peak, v)
+	}
+
+	var b strings.Builder
+	for row := chartHeight; row > 0; row-- {
+		b.WriteString("  ")
+		for _, v := range hourly {
+			// Высота столбца в строках с округлением вверх, чтобы малые значения были видны.
+			height := 0
+			if peak > 0 {
+				height = (v*chartHeight + peak - 1) / peak
+			}
+			if height >= row {
+				b.WriteString("█ ")
+			} else {
+				b.WriteString("  ")
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("  ")
+	for h := 0; h < len(hourly); h += 3 {
+		fmt.Fprintf(&b, "%-6s", fmt.Sprintf("%02d", h))
+	}
+	fmt.Fprintf(&b, "\n  максимум: %d шагов в час\n", peak)
+	return b.String()
+}
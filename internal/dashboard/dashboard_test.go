@@ -0,0 +1,80 @@
+package dashboard
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/recovery"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type DashboardTestSuite struct {
+	suite.Suite
+}
+
+func TestDashboardSuite(t *testing.T) {
+	suite.Run(t, new(DashboardTestSuite))
+}
+
+func (suite *DashboardTestSuite) TestProgress() {
+	assert.Equal(suite.T(), "Цель 10000: ["+strings.Repeat("█", 10)+reset+strings.Repeat("░", 30)+"] 25%\n", progress(2500, 10000))
+	assert.Equal(suite.T(), "Цель 1000: ["+green+strings.Repeat("█", 40)+reset+"] 150%\n", progress(1500, 1000))
+	assert.Empty(suite.T(), progress(1500, 0))
+}
+
+func (suite *DashboardTestSuite) TestChart() {
+	var hourly [24]int
+	hourly[8] = 800
+	hourly[9] = 100
+
+	lines := strings.Split(chart(hourly), "\n")
+	suite.Require().Len(lines, chartHeight+3)
+	// cell возвращает два символа столбца часа hour в строке line.
+	cell := func(line string, hour int) string {
+		r := []rune(line)
+		return string(r[2+hour*2 : 4+hour*2])
+	}
+	assert.Equal(suite.T(), "█ ", cell(lines[0], 8), "пиковый час занимает всю высоту")
+	assert.Equal(suite.T(), "  ", cell(lines[0], 9))
+	assert.Equal(suite.T(), "█ ", cell(lines[chartHeight-1], 9), "малое значение видно в нижней строке")
+	assert.Equal(suite.T(), "  максимум: 800 шагов в час", lines[chartHeight+1])
+}
+
+func (suite *DashboardTestSuite) TestRender() {
+	date := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	result, err := spentcalories.Summarize("6000,Бег,1h00m", 75, 1.75)
+	suite.Require().NoError(err)
+
+	got := Render(State{
+		Day: report.Day{
+			Date:      date,
+			Steps:     6000,
+			Distance:  3.9,
+			Calories:  177.19,
+			Trainings: []report.Training{{Time: date.Add(18 * time.Hour), Record: "6000,Бег,1h00m", Result: result}},
+		},
+		Goal:    10000,
+		Updated: date.Add(20 * time.Hour),
+	})
+
+	assert.True(suite.T(), strings.HasPrefix(got, clearScreen))
+	assert.Contains(suite.T(), got, "Активность за 19.10.2026")
+	assert.Contains(suite.T(), got, "Шаги:      6000\n")
+	assert.Contains(suite.T(), got, "] 60%\n")
+	assert.Contains(suite.T(), got, "  18:00  Бег          1.00 ч.  4.72 км  4.72 км/ч  354.38 ккал  12:42 мин/км\n")
+	assert.Contains(suite.T(), got, "Обновлено в 20:00:00")
+	assert.NotContains(suite.T(), got, "Сон:")
+}
+
+func (suite *DashboardTestSuite) TestRenderRecovery() {
+	date := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	sleep, err := recovery.ParseSleep("23:15,07:05,quality=4", date)
+	suite.Require().NoError(err)
+
+	got := Render(State{Day: report.Day{Date: date, Sleep: []recovery.Sleep{sleep}, Rest: &recovery.Rest{Note: "после полумарафона"}}})
+	assert.Contains(suite.T(), got, "Калории:   0.00 ккал\nСон:       7.83 ч., качество 4.0 из 5\nОтдых:     после полумарафона\n\n")
+}
@@ -1,20 +1,166 @@
 package daysteps
 
 import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/config"
+	"github.com/Yandex-Practicum/tracker/internal/meta"
+	"github.com/Yandex-Practicum/tracker/internal/parseerr"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
 )
 
 const (
-	// Длина одного шага в метрах
-	stepLength = 0.65
 	// Количество метров в одном километре
 	mInKm = 1000
 )
 
+// Calculator рассчитывает дневную активность с коэффициентами из конфигурации.
+type Calculator struct {
+	cfg      config.Config
+	calories spentcalories.Calculator
+	logger   *slog.Logger
+}
+
+// NewCalculator возвращает калькулятор дневной активности с коэффициентами cfg.
+func NewCalculator(cfg config.Config) Calculator {
+	return Calculator{cfg: cfg, calories: spentcalories.NewCalculator(cfg)}
+}
+
+// WithLogger возвращает копию калькулятора, пишущую в журнал logger.
+// По умолчанию используется slog.Default().
+func (c Calculator) WithLogger(logger *slog.Logger) Calculator {
+	c.logger = logger
+	c.calories = c.calories.WithLogger(logger)
+	return c
+}
+
+// log возвращает журнал калькулятора.
+func (c Calculator) log() *slog.Logger {
+	if c.logger == nil {
+		return slog.Default()
+	}
+	return c.logger
+}
+
+// defaultCalculator используется функциями пакета и работает с коэффициентами по умолчанию.
+var defaultCalculator = NewCalculator(config.Default())
+
+// parsePackage разбирает пакет данных вида "678,0h50m" на количество шагов и продолжительность.
 func parsePackage(data string) (int, time.Duration, error) {
-	// TODO: реализовать функцию
+	parts := strings.Split(data, ",")
+	if len(parts) != 2 {
+		return 0, 0, parseerr.New("", parseerr.KindFormat, "неверный формат данных: ожидается \"шаги,продолжительность\"")
+	}
+
+	steps, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, parseerr.New("steps", parseerr.KindSyntax, "некорректное количество шагов: %w", err)
+	}
+	if steps <= 0 {
+		return 0, 0, parseerr.New("steps", parseerr.KindRange, "количество шагов должно быть больше нуля: %d", steps)
+	}
+
+	duration, err := time.ParseDuration(parts[1])
+	if err != nil {
+		return 0, 0, parseerr.New("duration", parseerr.KindSyntax, "некорректная продолжительность: %w", err)
+	}
+	if duration <= 0 {
+		return 0, 0, parseerr.New("duration", parseerr.KindRange, "продолжительность должна быть больше нуля: %s", duration)
+	}
+
+	return steps, duration, nil
+}
+
+// parsePacket разбирает пакет parsePackage с необязательными метаданными
+// вида "ключ=значение" — источником, устройством, приложением, прошивкой
+// и партией импорта, например "678,0h50m,source=watch".
+func parsePacket(data string) (int, time.Duration, meta.Meta, error) {
+	fields := strings.Split(data, ",")
+	if len(fields) < 2 {
+		return 0, 0, meta.Meta{}, parseerr.New("", parseerr.KindFormat, "неверный формат данных: ожидается \"шаги,продолжительность\"")
+	}
+
+	m, rest, err := meta.Split(fields[2:])
+	if err != nil {
+		return 0, 0, meta.Meta{}, err
+	}
+	if len(rest) > 0 {
+		field := rest[0]
+		key, _, ok := strings.Cut(field, "=")
+		if !ok {
+			return 0, 0, meta.Meta{}, parseerr.New("", parseerr.KindFormat, "неверный параметр пакета %q: ожидается \"ключ=значение\"", field)
+		}
+		return 0, 0, meta.Meta{}, parseerr.New(key, parseerr.KindUnknown, "неизвестный параметр пакета: %q", key)
+	}
+
+	steps, duration, err := parsePackage(strings.Join(fields[:2], ","))
+	if err != nil {
+		return 0, 0, meta.Meta{}, err
+	}
+	return steps, duration, m, nil
+}
+
+// Summary — показатели пакета дневной активности.
+type Summary struct {
+	Steps    int
+	Duration time.Duration
+	Distance float64 // км.
+	Calories float64 // ккал.
+	// Meta — происхождение пакета.
+	Meta meta.Meta
 }
 
+// Summarize разбирает пакет дневной активности и рассчитывает его показатели.
+// Пакет может содержать метаданные: "678,0h50m,source=watch,device=Forerunner 255".
+func Summarize(data string, weight, height float64) (Summary, error) {
+	return defaultCalculator.Summarize(data, weight, height)
+}
+
+// Summarize рассчитывает показатели пакета с коэффициентами калькулятора.
+func (c Calculator) Summarize(data string, weight, height float64) (Summary, error) {
+	steps, duration, m, err := parsePacket(data)
+	if err != nil {
+		return Summary{}, err
+	}
+
+	calories, err := c.calories.WalkingSpentCalories(steps, weight, height, duration)
+	if err != nil {
+		return Summary{}, err
+	}
+
+	return Summary{
+		Steps:    steps,
+		Duration: duration,
+		Distance: float64(steps) * c.cfg.StepLength / mInKm,
+		Calories: calories,
+		Meta:     m,
+	}, nil
+}
+
+// DayActionInfo возвращает сводку по пакету дневной активности.
+// При ошибке разбора или расчёта пишет её в журнал и возвращает пустую строку.
 func DayActionInfo(data string, weight, height float64) string {
-	// TODO: реализовать функцию
+	return defaultCalculator.DayActionInfo(data, weight, height)
+}
+
+// DayActionInfo возвращает сводку по пакету дневной активности с коэффициентами калькулятора.
+func (c Calculator) DayActionInfo(data string, weight, height float64) string {
+	summary, err := c.Summarize(data, weight, height)
+	if err != nil {
+		c.log().Error("не удалось обработать пакет дневной активности", slog.String("record", data), parseerr.Attr(err))
+		return ""
+	}
+
+	return summary.Info()
+}
+
+// Info возвращает сводку в формате DayActionInfo. Метаданные пакета, если есть,
+// выводятся последней строкой.
+func (s Summary) Info() string {
+	return fmt.Sprintf("Количество шагов: %d.\nДистанция составила %.2f км.\nВы сожгли %.2f ккал.\n",
+		s.Steps, s.Distance, s.Calories) + s.Meta.Text()
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Yandex-Practicum/tracker/internal/meta"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -346,3 +347,27 @@ func (suite *DayStepsTestSuite) TestDayActionInfo() {
 		})
 	}
 }
+
+func (suite *DayStepsTestSuite) TestParsePacket() {
+	steps, duration, m, err := parsePacket("678,0h50m,source=watch,firmware=4.1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 678, steps)
+	assert.Equal(suite.T(), 50*time.Minute, duration)
+	assert.Equal(suite.T(), meta.Meta{Source: "watch", Firmware: "4.1"}, m)
+
+	_, _, m, err = parsePacket("678,0h50m")
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), m)
+
+	for _, data := range []string{
+		"678",
+		"678,0h50m,watch",
+		"678,0h50m,hr=120",
+		"678,0h50m,source=",
+		"678,0h50m,source=watch,source=phone",
+		"x,0h50m,source=watch",
+	} {
+		_, _, _, err := parsePacket(data)
+		assert.Error(suite.T(), err, data)
+	}
+}
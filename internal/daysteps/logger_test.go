@@ -0,0 +1,34 @@
+package daysteps
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/Yandex-Practicum/tracker/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *DayStepsTestSuite) TestDayActionInfoLogger() {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil)).With(slog.Int("line", 3))
+
+	got := NewCalculator(config.Default()).WithLogger(logger).DayActionInfo("0,1h00m", 75, 1.75)
+	assert.Empty(suite.T(), got)
+
+	var entry struct {
+		Level  string `json:"level"`
+		Line   int    `json:"line"`
+		Record string `json:"record"`
+		Error  struct {
+			Field string `json:"field"`
+			Kind  string `json:"kind"`
+		} `json:"error"`
+	}
+	suite.Require().NoError(json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(suite.T(), "ERROR", entry.Level)
+	assert.Equal(suite.T(), 3, entry.Line)
+	assert.Equal(suite.T(), "0,1h00m", entry.Record)
+	assert.Equal(suite.T(), "steps", entry.Error.Field)
+	assert.Equal(suite.T(), "range", entry.Error.Kind)
+}
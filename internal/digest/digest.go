@@ -0,0 +1,135 @@
+package digest
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+)
+
+// dateLayout — формат даты в дайджесте.
+const dateLayout = "02.01.2006"
+
+// Week — показатели одной недели.
+type Week struct {
+	report.Totals
+	// Best — тренировка с наибольшим расходом калорий, nil, если тренировок не было.
+	Best *report.Training
+	// LongestWalk — самая длинная по дистанции прогулка, nil, если прогулок не было.
+	LongestWalk *report.Training
+}
+
+// Digest — сводка за неделю в сравнении с предыдущей.
+type Digest struct {
+	From, To time.Time
+	Current  Week
+	Previous Week
+}
+
+// New возвращает дайджест по отчётам за текущую и предыдущую недели.
+func New(current, previous report.Report) Digest {
+	return Digest{
+		From:     current.From,
+		To:       current.To,
+		Current:  summarize(current),
+		Previous: summarize(previous),
+	}
+}
+
+// summarize рассчитывает показатели недели по отчёту.
+func summarize(r report.Report) Week {
+	w := Week{Totals: r.Totals()}
+	for i := range r.Days {
+		for j := range r.Days[i].Trainings {
+			tr := &r.Days[i].Trainings[j]
+			if w.Best == nil || tr.Result.Calories > w.Best.Result.Calories {
+				w.Best = tr
+			}
+			if tr.Result.Type() == spentcalories.WalkingType &&
+				(w.LongestWalk == nil || tr.Result.Distance > w.LongestWalk.Result.Distance) {
+				w.LongestWalk = tr
+			}
+		}
+	}
+	return w
+}
+
+// Distance возвращает дистанцию за неделю: по шагам и на тренировках, км.
+func (w Week) Distance() float64 {
+	return w.Totals.Distance + w.TrainingDistance
+}
+
+// Calories возвращает калории за неделю: по шагам и на тренировках, ккал.
+func (w Week) Calories() float64 {
+	return w.Totals.Calories + w.TrainingCalories
+}
+
+// style задаёт разметку дайджеста.
+type style struct {
+	heading string
+	bullet  string
+	strong  string
+}
+
+var (
+	markdown  = style{heading: "## ", bullet: "- ", strong: "**"}
+	plainText = style{bullet: "• "}
+)
+
+// Markdown возвращает дайджест в разметке Markdown.
+func (d Digest) Markdown() string {
+	return d.render(markdown)
+}
+
+// Text возвращает дайджест простым текстом.
+func (d Digest) Text() string {
+	return d.render(plainText)
+}
+
+// render возвращает дайджест в разметке s.
+func (d Digest) render(s style) string {
+	var b strings.Builder
+	cur, prev := d.Current, d.Previous
+
+	fmt.Fprintf(&b, "%sНеделя %s — %s\n\n", s.heading, d.From.Format(dateLayout), d.To.Add(-time.Nanosecond).Format(dateLayout))
+	fmt.Fprintf(&b, "%sТренировок: %d (неделей ранее %d%s)\n", s.bullet,
+		cur.Trainings, prev.Trainings, change(float64(cur.Trainings), float64(prev.Trainings)))
+	fmt.Fprintf(&b, "%sДистанция: %.2f км (неделей ранее %.2f км%s)\n", s.bullet,
+		cur.Distance(), prev.Distance(), change(cur.Distance(), prev.Distance()))
+	fmt.Fprintf(&b, "%sКалории: %.2f ккал (неделей ранее %.2f ккал%s)\n", s.bullet,
+		cur.Calories(), prev.Calories(), change(cur.Calories(), prev.Calories()))
+	fmt.Fprintf(&b, "%sШагов: %d (неделей ранее %d%s)\n", s.bullet,
+		cur.Steps, prev.Steps, change(float64(cur.Steps), float64(prev.Steps)))
+
+	if cur.Best != nil {
+		fmt.Fprintf(&b, "%sЛучшая тренировка: %s\n", s.bullet, training(*cur.Best, s))
+	}
+	if cur.LongestWalk != nil {
+		fmt.Fprintf(&b, "%sСамая длинная прогулка: %s\n", s.bullet, training(*cur.LongestWalk, s))
+	}
+
+	return b.String()
+}
+
+// training возвращает описание тренировки одной строкой.
+func training(tr report.Training, s style) string {
+	return fmt.Sprintf("%s%s%s %s, %.2f ч., %.2f км, %.2f км/ч, %.2f ккал",
+		s.strong, tr.Result.Type(), s.strong, tr.Time.Format(dateLayout+" 15:04"),
+		tr.Result.Duration.Hours(), tr.Result.Distance, tr.Result.Speed, tr.Result.Calories)
+}
+
+// change возвращает изменение cur относительно prev в процентах
+// или пустую строку, если на предыдущей неделе показатель был нулевым.
+func change(cur, prev float64) string {
+	if prev == 0 {
+		return ""
+	}
+	pct := math.Round((cur - prev) / prev * 100)
+	if pct == 0 {
+		return ", без изменений"
+	}
+	return fmt.Sprintf(", %+.0f%%", pct)
+}
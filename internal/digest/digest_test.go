@@ -0,0 +1,74 @@
+package digest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/config"
+	"github.com/Yandex-Practicum/tracker/internal/journal"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type DigestTestSuite struct {
+	suite.Suite
+	digest Digest
+}
+
+func TestDigestSuite(t *testing.T) {
+	suite.Run(t, new(DigestTestSuite))
+}
+
+func (suite *DigestTestSuite) SetupTest() {
+	at := func(d, h int) time.Time { return time.Date(2026, 10, d, h, 0, 0, 0, time.UTC) }
+	entries := []journal.Entry{
+		{Time: at(6, 18), Kind: journal.KindTraining, Record: "6000,Бег,1h00m"},
+		{Time: at(13, 8), Kind: journal.KindSteps, Record: "5000,1h00m"},
+		{Time: at(13, 18), Kind: journal.KindTraining, Record: "6000,Бег,1h00m"},
+		{Time: at(14, 18), Kind: journal.KindTraining, Record: "6000,Ходьба,1h00m"},
+		{Time: at(15, 18), Kind: journal.KindTraining, Record: "9000,Ходьба,2h00m"},
+	}
+	p := profile.Profile{Weight: 75, Height: 1.75}
+	b := report.NewBuilder(config.Default(), nil)
+	from := at(12, 0)
+	suite.digest = New(b.Build(entries, p, from, from.AddDate(0, 0, 7)), b.Build(entries, p, from.AddDate(0, 0, -7), from))
+}
+
+func (suite *DigestTestSuite) TestNew() {
+	cur := suite.digest.Current
+	assert.Equal(suite.T(), 3, cur.Trainings)
+	suite.Require().NotNil(cur.Best)
+	assert.Equal(suite.T(), "6000,Бег,1h00m", cur.Best.Record)
+	suite.Require().NotNil(cur.LongestWalk)
+	assert.Equal(suite.T(), "9000,Ходьба,2h00m", cur.LongestWalk.Record)
+
+	prev := suite.digest.Previous
+	assert.Equal(suite.T(), 1, prev.Trainings)
+	assert.Nil(suite.T(), prev.LongestWalk)
+}
+
+func (suite *DigestTestSuite) TestMarkdown() {
+	got := suite.digest.Markdown()
+	assert.Contains(suite.T(), got, "## Неделя 12.10.2026 — 18.10.2026\n\n")
+	assert.Contains(suite.T(), got, "- Тренировок: 3 (неделей ранее 1, +200%)\n")
+	assert.Contains(suite.T(), got, "- Шагов: 5000 (неделей ранее 0)\n")
+	assert.Contains(suite.T(), got, "- Лучшая тренировка: **Бег** 13.10.2026 18:00, 1.00 ч., 4.72 км, 4.72 км/ч, 354.38 ккал\n")
+	assert.Contains(suite.T(), got, "- Самая длинная прогулка: **Ходьба** 15.10.2026 18:00")
+}
+
+func (suite *DigestTestSuite) TestText() {
+	got := suite.digest.Text()
+	assert.Contains(suite.T(), got, "Неделя 12.10.2026 — 18.10.2026\n\n")
+	assert.Contains(suite.T(), got, "• Лучшая тренировка: Бег 13.10.2026 18:00")
+	assert.NotContains(suite.T(), got, "**")
+	assert.NotContains(suite.T(), got, "##")
+}
+
+func (suite *DigestTestSuite) TestChange() {
+	assert.Equal(suite.T(), "", change(5, 0))
+	assert.Equal(suite.T(), ", без изменений", change(10, 10))
+	assert.Equal(suite.T(), ", -50%", change(5, 10))
+	assert.Equal(suite.T(), ", +25%", change(5, 4))
+}
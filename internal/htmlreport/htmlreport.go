@@ -0,0 +1,89 @@
+package htmlreport
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/chart"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+)
+
+//go:embed report.html.tmpl
+var source string
+
+// page — шаблон страницы отчёта.
+var page = template.Must(template.New("report").Funcs(template.FuncMap{
+	"date":  func(t time.Time) string { return t.Format("02.01.2006") },
+	"clock": func(t time.Time) string { return t.Format("15:04") },
+	"hours": func(d time.Duration) string { return fmt.Sprintf("%.2f", d.Hours()) },
+	"f2":    func(v float64) string { return fmt.Sprintf("%.2f", v) },
+	"pct":   func(v float64) string { return fmt.Sprintf("%.0f", v*100) },
+	"pace": func(tr report.Training) string {
+		if p, ok := tr.Pace(); ok {
+			return p.Clock()
+		}
+		return "—"
+	},
+}).Parse(source))
+
+// view — данные для шаблона.
+type view struct {
+	Athlete string
+	Report  report.Report
+	Last    time.Time // последний день периода.
+	Goal    int
+	Days    []dayView
+	Totals  report.Totals
+	GoalMet int // число дней с выполненной целью.
+	Charts  []template.HTML
+}
+
+// dayView — день отчёта с прогрессом к цели.
+type dayView struct {
+	report.Day
+	Progress float64 // доля цели, может быть больше единицы.
+	Bar      float64 // ширина полосы прогресса в процентах, не больше 100.
+}
+
+// Write записывает отчёт r спортсмена athlete в виде одной HTML-страницы
+// без внешних ресурсов: стили и диаграммы SVG встроены в документ.
+func Write(w io.Writer, r report.Report, p profile.Profile, athlete string) error {
+	v := view{
+		Athlete: athlete,
+		Report:  r,
+		Last:    r.To.Add(-time.Nanosecond),
+		Goal:    p.Goal(),
+		Totals:  r.Totals(),
+	}
+
+	for _, d := range r.Days {
+		progress := float64(d.Steps) / float64(v.Goal)
+		if progress >= 1 {
+			v.GoalMet++
+		}
+		v.Days = append(v.Days, dayView{Day: d, Progress: progress, Bar: min(progress, 1) * 100})
+	}
+
+	for _, kind := range []chart.Kind{chart.KindSteps, chart.KindCalories, chart.KindPace} {
+		s, err := chart.FromReport(r, kind)
+		if err != nil {
+			return err
+		}
+		if len(s.Values) == 0 {
+			continue
+		}
+		var buf bytes.Buffer
+		if err := chart.SVG(&buf, s); err != nil {
+			return err
+		}
+		// chart.SVG экранирует все подписи, поэтому разметку можно вставлять как есть.
+		v.Charts = append(v.Charts, template.HTML(buf.String()))
+	}
+
+	return page.Execute(w, v)
+}
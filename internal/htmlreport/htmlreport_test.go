@@ -0,0 +1,58 @@
+package htmlreport
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/config"
+	"github.com/Yandex-Practicum/tracker/internal/journal"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type HTMLReportTestSuite struct {
+	suite.Suite
+}
+
+func TestHTMLReportSuite(t *testing.T) {
+	suite.Run(t, new(HTMLReportTestSuite))
+}
+
+func (suite *HTMLReportTestSuite) TestWrite() {
+	at := func(d, h int) time.Time { return time.Date(2026, 10, d, h, 0, 0, 0, time.UTC) }
+	entries := []journal.Entry{
+		{Time: at(12, 8), Kind: journal.KindSteps, Record: "12000,2h00m"},
+		{Time: at(13, 8), Kind: journal.KindSteps, Record: "5000,1h00m"},
+		{Time: at(13, 18), Kind: journal.KindTraining, Record: "6000,Бег,1h00m"},
+	}
+	p := profile.Profile{Weight: 75, Height: 1.75}
+	r := report.NewBuilder(config.Default(), nil).Build(entries, p, at(12, 0), at(14, 0))
+
+	var buf bytes.Buffer
+	suite.Require().NoError(Write(&buf, r, p, "Иван <И.>"))
+	got := buf.String()
+
+	assert.True(suite.T(), strings.HasPrefix(got, "<!DOCTYPE html>"))
+	assert.Contains(suite.T(), got, "<h1>Иван &lt;И.&gt;: отчёт за 12.10.2026 — 13.10.2026</h1>")
+	assert.Contains(suite.T(), got, "<td>Дней с выполненной целью 10000 шагов</td><td>1 из 2</td>")
+	assert.Contains(suite.T(), got, `<td class="met">120%</td>`)
+	assert.Contains(suite.T(), got, `<div style="width: 100.00%">`)
+	assert.Contains(suite.T(), got, "<td>13.10.2026</td><td>18:00</td><td>Бег</td><td>1.00</td>\n<td>4.72</td><td>4.72</td><td>12:42</td><td>354.38</td>")
+	assert.Equal(suite.T(), 3, strings.Count(got, "<svg "), "шаги, калории и темп")
+	assert.NotContains(suite.T(), got, "&lt;svg")
+	assert.NotContains(suite.T(), got, "src=", "страница не должна ссылаться на внешние ресурсы")
+}
+
+func (suite *HTMLReportTestSuite) TestWriteEmpty() {
+	from := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	r := report.NewBuilder(config.Default(), nil).Build(nil, profile.Profile{Weight: 75, Height: 1.75}, from, from.AddDate(0, 0, 7))
+
+	var buf bytes.Buffer
+	suite.Require().NoError(Write(&buf, r, profile.Profile{Weight: 75, Height: 1.75}, ""))
+	assert.Contains(suite.T(), buf.String(), "<h1>отчёт за 12.10.2026 — 18.10.2026</h1>")
+	assert.Contains(suite.T(), buf.String(), "Тренировок не было.")
+}
@@ -0,0 +1,104 @@
+package ical
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Yandex-Practicum/tracker/internal/report"
+)
+
+const (
+	// stampLayout — формат даты и времени UTC по RFC 5545.
+	stampLayout = "20060102T150405Z"
+	// lineLimit — наибольшая длина строки содержимого в октетах без перевода строки.
+	lineLimit = 75
+	// productID — идентификатор программы, создавшей календарь.
+	productID = "-//Yandex Practicum//tracker//RU"
+)
+
+// Write записывает тренировки отчёта r календарём iCalendar (RFC 5545),
+// по одному событию VEVENT на тренировку. stamp — момент выгрузки
+// для свойства DTSTAMP.
+func Write(w io.Writer, r report.Report, stamp time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeLine(bw, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", productID)
+	line("CALSCALE", "GREGORIAN")
+	for _, d := range r.Days {
+		for _, tr := range d.Trainings {
+			res := tr.Result
+			line("BEGIN", "VEVENT")
+			line("UID", uid(tr))
+			line("DTSTAMP", stamp.UTC().Format(stampLayout))
+			line("DTSTART", tr.Time.UTC().Format(stampLayout))
+			line("DURATION", duration(res.Duration))
+			line("SUMMARY", escape(res.Type()))
+			line("DESCRIPTION", escape(fmt.Sprintf("Дистанция: %.2f км.\nСкорость: %.2f км/ч\nСожгли калорий: %.2f",
+				res.Distance, res.Speed, res.Calories)))
+			line("END", "VEVENT")
+		}
+	}
+	line("END", "VCALENDAR")
+
+	return bw.Flush()
+}
+
+// uid возвращает идентификатор события, постоянный для одной и той же записи журнала,
+// чтобы при повторном импорте календарь обновлял события, а не дублировал их.
+func uid(tr report.Training) string {
+	sum := sha1.Sum([]byte(tr.Time.UTC().Format(time.RFC3339) + "\t" + tr.Record))
+	return hex.EncodeToString(sum[:]) + "@tracker"
+}
+
+// duration форматирует продолжительность по RFC 5545, например "PT1H30M".
+// Грамматика не позволяет пропустить минуты между часами и секундами.
+func duration(d time.Duration) string {
+	d = d.Round(time.Second)
+	h, m, s := int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60
+
+	var b strings.Builder
+	b.WriteString("PT")
+	if h > 0 {
+		fmt.Fprintf(&b, "%dH", h)
+	}
+	if m > 0 || h > 0 && s > 0 {
+		fmt.Fprintf(&b, "%dM", m)
+	}
+	if s > 0 || h == 0 && m == 0 {
+		fmt.Fprintf(&b, "%dS", s)
+	}
+	return b.String()
+}
+
+// escape экранирует значение типа TEXT.
+var escape = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace
+
+// writeLine записывает строку содержимого, перенося её через CRLF и пробел,
+// если она длиннее lineLimit октетов. Многобайтные символы UTF-8 не разрываются.
+func writeLine(w *bufio.Writer, s string) {
+	limit := lineLimit
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.WriteString(s[:cut])
+		w.WriteString("\r\n ")
+		s = s[cut:]
+		// Продолжение начинается с пробела, который входит в длину строки.
+		limit = lineLimit - 1
+	}
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
@@ -0,0 +1,86 @@
+package ical
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Yandex-Practicum/tracker/internal/config"
+	"github.com/Yandex-Practicum/tracker/internal/journal"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ICalTestSuite struct {
+	suite.Suite
+}
+
+func TestICalSuite(t *testing.T) {
+	suite.Run(t, new(ICalTestSuite))
+}
+
+func (suite *ICalTestSuite) TestWrite() {
+	msk := time.FixedZone("MSK", 3*60*60)
+	at := time.Date(2026, 10, 12, 18, 0, 0, 0, msk)
+	entries := []journal.Entry{
+		{Time: at.Add(-8 * time.Hour), Kind: journal.KindSteps, Record: "5000,1h00m"},
+		{Time: at, Kind: journal.KindTraining, Record: "6000,Бег,1h30m"},
+	}
+	from := time.Date(2026, 10, 12, 0, 0, 0, 0, msk)
+	r := report.NewBuilder(config.Default(), nil).Build(entries, profile.Profile{Weight: 75, Height: 1.75}, from, from.AddDate(0, 0, 1))
+
+	var buf bytes.Buffer
+	suite.Require().NoError(Write(&buf, r, time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)))
+	got := buf.String()
+
+	assert.True(suite.T(), strings.HasPrefix(got, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(suite.T(), strings.HasSuffix(got, "END:VEVENT\r\nEND:VCALENDAR\r\n"))
+	assert.Equal(suite.T(), 1, strings.Count(got, "BEGIN:VEVENT"))
+	assert.Contains(suite.T(), got, "DTSTAMP:20261019T090000Z\r\n")
+	assert.Contains(suite.T(), got, "DTSTART:20261012T150000Z\r\n")
+	assert.Contains(suite.T(), got, "DURATION:PT1H30M\r\n")
+	assert.Contains(suite.T(), got, "SUMMARY:Бег\r\n")
+
+	// После снятия переносов описание содержит показатели TrainingInfo.
+	unfolded := strings.ReplaceAll(got, "\r\n ", "")
+	assert.Contains(suite.T(), unfolded, `DESCRIPTION:Дистанция: 4.72 км.\nСкорость: 3.15 км/ч\nСожгли калорий: 354.38`+"\r\n")
+
+	sc := bufio.NewScanner(strings.NewReader(got))
+	for sc.Scan() {
+		assert.LessOrEqual(suite.T(), len(sc.Text()), lineLimit+1, sc.Text())
+	}
+}
+
+func (suite *ICalTestSuite) TestDuration() {
+	assert.Equal(suite.T(), "PT45M", duration(45*time.Minute))
+	assert.Equal(suite.T(), "PT2H", duration(2*time.Hour))
+	assert.Equal(suite.T(), "PT1H0M5S", duration(time.Hour+5*time.Second))
+	assert.Equal(suite.T(), "PT0S", duration(0))
+}
+
+func (suite *ICalTestSuite) TestEscape() {
+	assert.Equal(suite.T(), `a\, b\; c\\d\ne`, escape("a, b; c\\d\ne"))
+}
+
+func (suite *ICalTestSuite) TestWriteLine() {
+	var buf bytes.Buffer
+	bw := bufio.NewWriter(&buf)
+	writeLine(bw, "DESCRIPTION:"+strings.Repeat("ж", 70))
+	suite.Require().NoError(bw.Flush())
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	suite.Require().Len(lines, 3)
+	for i, l := range lines {
+		assert.LessOrEqual(suite.T(), len(l), lineLimit)
+		assert.True(suite.T(), utf8.ValidString(l), "строка %d разорвала символ", i)
+		if i > 0 {
+			assert.True(suite.T(), strings.HasPrefix(l, " "))
+		}
+	}
+	assert.Equal(suite.T(), "DESCRIPTION:"+strings.Repeat("ж", 70), strings.ReplaceAll(buf.String()[:buf.Len()-2], "\r\n ", ""))
+}
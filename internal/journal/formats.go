@@ -0,0 +1,171 @@
+package journal
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/parseerr"
+)
+
+// Format — формат обмена записями журнала.
+type Format string
+
+// Поддерживаемые форматы обмена.
+const (
+	FormatText  Format = "text"  // формат файла журнала.
+	FormatCSV   Format = "csv"   // CSV с заголовком time,kind,record.
+	FormatJSONL Format = "jsonl" // объект JSON на строку.
+)
+
+// csvHeader — заголовок CSV.
+var csvHeader = []string{"time", "kind", "record"}
+
+// jsonEntry — представление записи в JSON.
+type jsonEntry struct {
+	Time   time.Time `json:"time"`
+	Kind   Kind      `json:"kind"`
+	Record string    `json:"record"`
+}
+
+// ParseFormat проверяет название формата.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatText, FormatCSV, FormatJSONL:
+		return f, nil
+	default:
+		return "", fmt.Errorf("неизвестный формат: %q", s)
+	}
+}
+
+// Decode читает записи в формате f.
+func Decode(r io.Reader, f Format) ([]Entry, error) {
+	switch f {
+	case FormatText:
+		return Read(r)
+	case FormatCSV:
+		return readCSV(r)
+	case FormatJSONL:
+		return readJSONL(r)
+	default:
+		return nil, fmt.Errorf("неизвестный формат: %q", f)
+	}
+}
+
+// Encode записывает записи в формате f.
+func Encode(w io.Writer, entries []Entry, f Format) error {
+	switch f {
+	case FormatText:
+		return Write(w, entries)
+	case FormatCSV:
+		return writeCSV(w, entries)
+	case FormatJSONL:
+		return writeJSONL(w, entries)
+	default:
+		return fmt.Errorf("неизвестный формат: %q", f)
+	}
+}
+
+func readCSV(r io.Reader) ([]Entry, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(csvHeader)
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать CSV: %w", err)
+	}
+	for i, name := range csvHeader {
+		if header[i] != name {
+			return nil, parseerr.New("", parseerr.KindFormat, "неверный заголовок CSV: ожидается %v", csvHeader)
+		}
+	}
+
+	var entries []Entry
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("не удалось прочитать CSV: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+
+		entry, err := newEntry(rec[0], rec[1], rec[2])
+		if err != nil {
+			return nil, fmt.Errorf("строка %d: %w", line, err)
+		}
+		entry.Line = line
+		entries = append(entries, entry)
+	}
+}
+
+func writeCSV(w io.Writer, entries []Entry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := cw.Write([]string{e.Time.Format(time.RFC3339), string(e.Kind), e.Record}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func readJSONL(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	for n := 1; ; n++ {
+		var je jsonEntry
+		err := dec.Decode(&je)
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("запись %d: %w", n, parseerr.New("", parseerr.KindSyntax, "некорректный JSON: %w", err))
+		}
+
+		entry := Entry{Time: je.Time, Kind: je.Kind, Record: je.Record, Line: n}
+		if err := entry.validate(); err != nil {
+			return nil, fmt.Errorf("запись %d: %w", n, err)
+		}
+		entries = append(entries, entry)
+	}
+}
+
+func writeJSONL(w io.Writer, entries []Entry) error {
+	enc := json.NewEncoder(w)
+	for _, e := range entries {
+		if err := enc.Encode(jsonEntry{Time: e.Time, Kind: e.Kind, Record: e.Record}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newEntry собирает запись из текстовых полей и проверяет её.
+func newEntry(timeField, kindField, record string) (Entry, error) {
+	t, err := time.Parse(time.RFC3339, timeField)
+	if err != nil {
+		return Entry{}, parseerr.New("time", parseerr.KindSyntax, "некорректное время записи: %w", err)
+	}
+	kind, err := ParseKind(kindField)
+	if err != nil {
+		return Entry{}, err
+	}
+	entry := Entry{Time: t, Kind: kind, Record: record}
+	if err := entry.validate(); err != nil {
+		return Entry{}, err
+	}
+	return entry, nil
+}
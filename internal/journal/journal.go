@@ -0,0 +1,187 @@
+package journal
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/meta"
+	"github.com/Yandex-Practicum/tracker/internal/parseerr"
+)
+
+// Kind — вид записи журнала.
+type Kind string
+
+// Виды записей журнала.
+const (
+	KindSteps    Kind = "steps"    // пакет дневной активности, например "678,0h50m".
+	KindTraining Kind = "training" // тренировка, например "3456,Ходьба,3h00m".
+	KindSleep    Kind = "sleep"    // ночной сон, например "23:15,07:05,quality=4".
+	KindRest     Kind = "rest"     // запланированный день отдыха, например "после полумарафона".
+	KindFood     Kind = "food"     // приём пищи, например "Завтрак,520,protein=30".
+)
+
+// fieldSeparator разделяет поля строки журнала.
+const fieldSeparator = "\t"
+
+// Entry — запись журнала: момент начала, вид и исходная строка данных.
+type Entry struct {
+	Time   time.Time
+	Kind   Kind
+	Record string
+	// Line — номер строки в файле, из которого прочитана запись; 0 для новых записей.
+	Line int
+}
+
+// ParseKind проверяет вид записи.
+func ParseKind(s string) (Kind, error) {
+	switch k := Kind(s); k {
+	case KindSteps, KindTraining, KindSleep, KindRest, KindFood:
+		return k, nil
+	default:
+		return "", parseerr.New("kind", parseerr.KindUnknown, "неизвестный вид записи: %q", s)
+	}
+}
+
+// Read читает журнал в текстовом формате: по записи на строку,
+// поля "время RFC 3339<TAB>вид<TAB>данные". Пустые строки и строки,
+// начинающиеся с "#", пропускаются.
+func Read(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if strings.TrimSpace(text) == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		entry, err := parseLine(text)
+		if err != nil {
+			return nil, fmt.Errorf("строка %d: %w", line, err)
+		}
+		entry.Line = line
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось прочитать журнал: %w", err)
+	}
+
+	return entries, nil
+}
+
+// parseLine разбирает строку журнала.
+func parseLine(text string) (Entry, error) {
+	parts := strings.Split(text, fieldSeparator)
+	if len(parts) != 3 {
+		return Entry{}, parseerr.New("", parseerr.KindFormat, "неверный формат записи: ожидается \"время<TAB>вид<TAB>данные\"")
+	}
+
+	return newEntry(parts[0], parts[1], parts[2])
+}
+
+// Write записывает журнал в текстовом формате, который читает Read.
+func Write(w io.Writer, entries []Entry) error {
+	bw := bufio.NewWriter(w)
+	for _, e := range entries {
+		if err := e.validate(); err != nil {
+			return err
+		}
+		fmt.Fprintf(bw, "%s%s%s%s%s\n", e.Time.Format(time.RFC3339), fieldSeparator, e.Kind, fieldSeparator, e.Record)
+	}
+	return bw.Flush()
+}
+
+// validate проверяет, что запись можно сохранить в текстовом формате.
+func (e Entry) validate() error {
+	if _, err := ParseKind(string(e.Kind)); err != nil {
+		return err
+	}
+	if e.Record == "" || strings.ContainsAny(e.Record, fieldSeparator+"\n") {
+		return parseerr.New("record", parseerr.KindFormat, "данные записи пусты или содержат табуляцию либо перевод строки: %q", e.Record)
+	}
+	return nil
+}
+
+// Load читает журнал из файла. Отсутствующий файл считается пустым журналом.
+func Load(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось открыть журнал: %w", err)
+	}
+	defer f.Close()
+
+	entries, err := Read(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// Save перезаписывает файл журнала записями entries, упорядоченными по времени.
+func Save(path string, entries []Entry) error {
+	sorted := make([]Entry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("не удалось сохранить журнал: %w", err)
+	}
+	if err := Write(f, sorted); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("не удалось сохранить журнал: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// Append добавляет запись в конец файла журнала, создавая файл при необходимости.
+func Append(path string, e Entry) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("не удалось открыть журнал: %w", err)
+	}
+	if err := Write(f, []Entry{e}); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Between возвращает записи с временем в полуинтервале [from, to).
+func Between(entries []Entry, from, to time.Time) []Entry {
+	var res []Entry
+	for _, e := range entries {
+		if !e.Time.Before(from) && e.Time.Before(to) {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+// FromSource возвращает записи, у которых источник, устройство или приложение
+// в метаданных совпадает с source.
+func FromSource(entries []Entry, source string) []Entry {
+	var res []Entry
+	for _, e := range entries {
+		if meta.FromRecord(e.Record).Matches(source) {
+			res = append(res, e)
+		}
+	}
+	return res
+}
@@ -0,0 +1,158 @@
+package journal
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type JournalTestSuite struct {
+	suite.Suite
+}
+
+func TestJournalSuite(t *testing.T) {
+	suite.Run(t, new(JournalTestSuite))
+}
+
+var (
+	morning = time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)
+	evening = time.Date(2026, 10, 19, 19, 0, 0, 0, time.UTC)
+)
+
+func (suite *JournalTestSuite) TestRead() {
+	tests := []struct {
+		name    string
+		input   string
+		want    []Entry
+		wantErr bool
+	}{
+		{
+			name:  "записи, комментарии и пустые строки",
+			input: "# журнал\n2026-10-19T08:30:00Z\tsteps\t678,0h50m\n\n2026-10-19T19:00:00Z\ttraining\t3456,Ходьба,3h00m\n",
+			want: []Entry{
+				{Time: morning, Kind: KindSteps, Record: "678,0h50m", Line: 2},
+				{Time: evening, Kind: KindTraining, Record: "3456,Ходьба,3h00m", Line: 4},
+			},
+		},
+		{
+			name:  "пустой журнал",
+			input: "",
+			want:  nil,
+		},
+		{
+			name:    "неверное количество полей",
+			input:   "2026-10-19T08:30:00Z\tsteps\n",
+			wantErr: true,
+		},
+		{
+			name:    "некорректное время",
+			input:   "19.10.2026\tsteps\t678,0h50m\n",
+			wantErr: true,
+		},
+		{
+			name:    "неизвестный вид записи",
+			input:   "2026-10-19T08:30:00Z\tyoga\t1h\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			got, err := Read(strings.NewReader(tt.input))
+
+			if tt.wantErr {
+				assert.Error(suite.T(), err)
+				return
+			}
+
+			assert.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tt.want, got)
+		})
+	}
+}
+
+func (suite *JournalTestSuite) TestSaveLoadAppend() {
+	path := filepath.Join(suite.T().TempDir(), "journal.txt")
+
+	got, err := Load(path)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), got)
+
+	suite.Require().NoError(Save(path, []Entry{
+		{Time: evening, Kind: KindTraining, Record: "3456,Ходьба,3h00m"},
+		{Time: morning, Kind: KindSteps, Record: "678,0h50m"},
+	}))
+	suite.Require().NoError(Append(path, Entry{Time: evening.Add(time.Hour), Kind: KindSteps, Record: "100,5m"}))
+
+	got, err = Load(path)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []Entry{
+		{Time: morning, Kind: KindSteps, Record: "678,0h50m", Line: 1},
+		{Time: evening, Kind: KindTraining, Record: "3456,Ходьба,3h00m", Line: 2},
+		{Time: evening.Add(time.Hour), Kind: KindSteps, Record: "100,5m", Line: 3},
+	}, got)
+
+	err = Append(path, Entry{Time: morning, Kind: KindSteps, Record: "100\t5m"})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *JournalTestSuite) TestFormats() {
+	entries := []Entry{
+		{Time: morning, Kind: KindSteps, Record: "678,0h50m,source=watch,device=Forerunner 255,batch=b1"},
+		{Time: evening, Kind: KindTraining, Record: "1200,Бег,5m;400,Ходьба,2m"},
+	}
+
+	for _, f := range []Format{FormatText, FormatCSV, FormatJSONL} {
+		suite.Run(string(f), func() {
+			var buf bytes.Buffer
+			suite.Require().NoError(Encode(&buf, entries, f))
+
+			got, err := Decode(&buf, f)
+			suite.Require().NoError(err)
+			suite.Require().Len(got, len(entries))
+			for i := range entries {
+				assert.True(suite.T(), entries[i].Time.Equal(got[i].Time))
+				assert.Equal(suite.T(), entries[i].Kind, got[i].Kind)
+				assert.Equal(suite.T(), entries[i].Record, got[i].Record)
+			}
+		})
+	}
+
+	_, err := Decode(strings.NewReader("when,kind,record\n"), FormatCSV)
+	assert.Error(suite.T(), err)
+
+	_, err = Decode(strings.NewReader(`{"time":"2026-10-19T08:30:00Z","kind":"steps","record":""}`), FormatJSONL)
+	assert.Error(suite.T(), err)
+
+	_, err = ParseFormat("xml")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *JournalTestSuite) TestBetween() {
+	entries := []Entry{
+		{Time: morning, Kind: KindSteps, Record: "678,0h50m"},
+		{Time: evening, Kind: KindSteps, Record: "100,5m"},
+	}
+
+	got := Between(entries, morning, evening)
+	assert.Equal(suite.T(), entries[:1], got)
+}
+
+func (suite *JournalTestSuite) TestFromSource() {
+	entries := []Entry{
+		{Time: morning, Kind: KindSteps, Record: "678,0h50m,source=watch"},
+		{Time: morning, Kind: KindSteps, Record: "700,0h50m,source=phone,app=Health"},
+		{Time: evening, Kind: KindTraining, Record: "1200,Бег,5m,device=Forerunner 255;400,Ходьба,2m"},
+		{Time: evening, Kind: KindSteps, Record: "100,5m"},
+	}
+
+	assert.Equal(suite.T(), entries[:1], FromSource(entries, "watch"))
+	assert.Equal(suite.T(), entries[1:2], FromSource(entries, "Health"))
+	assert.Equal(suite.T(), entries[2:3], FromSource(entries, "Forerunner 255"))
+	assert.Empty(suite.T(), FromSource(entries, ""))
+}
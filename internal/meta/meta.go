@@ -0,0 +1,179 @@
+package meta
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/parseerr"
+	"github.com/Yandex-Practicum/tracker/internal/zone"
+)
+
+// Ключи параметров записи с её происхождением.
+const (
+	KeySource   = "source"   // источник: устройство или приложение, передавшее запись.
+	KeyDevice   = "device"   // модель устройства.
+	KeyApp      = "app"      // приложение.
+	KeyFirmware = "firmware" // версия прошивки устройства.
+	KeyBatch    = "batch"    // идентификатор партии импорта.
+	KeyZone     = "tz"       // часовой пояс IANA, в котором сделана запись.
+)
+
+// Разделители записи: полей, отрезков интервальной тренировки, ключа и значения.
+const (
+	fieldSeparator   = ","
+	segmentSeparator = ";"
+	valueSeparator   = "="
+)
+
+// Meta — происхождение записи. Пустое значение поля означает, что оно не указано.
+type Meta struct {
+	Source   string
+	Device   string
+	App      string
+	Firmware string
+	Batch    string
+	// Zone — часовой пояс IANA, по которому запись относится к дню,
+	// например у путешественника; пусто — пояс пользователя.
+	Zone string
+}
+
+// field возвращает поле m для ключа key или nil для ключа не из метаданных.
+func (m *Meta) field(key string) *string {
+	switch key {
+	case KeySource:
+		return &m.Source
+	case KeyDevice:
+		return &m.Device
+	case KeyApp:
+		return &m.App
+	case KeyFirmware:
+		return &m.Firmware
+	case KeyBatch:
+		return &m.Batch
+	case KeyZone:
+		return &m.Zone
+	default:
+		return nil
+	}
+}
+
+// keys — ключи метаданных в порядке вывода.
+var keys = []string{KeySource, KeyDevice, KeyApp, KeyFirmware, KeyBatch, KeyZone}
+
+// Split отделяет от необязательных параметров записи fields вида "ключ=значение"
+// метаданные и возвращает их вместе с остальными параметрами в исходном порядке.
+func Split(fields []string) (Meta, []string, error) {
+	var (
+		m    Meta
+		rest []string
+	)
+	for _, f := range fields {
+		key, value, _ := strings.Cut(f, valueSeparator)
+		p := m.field(key)
+		switch {
+		case p == nil:
+			rest = append(rest, f)
+			continue
+		case *p != "":
+			return Meta{}, nil, parseerr.New(key, parseerr.KindConflict, "параметр записи %q указан повторно", key)
+		}
+		if err := validateValue(key, value); err != nil {
+			return Meta{}, nil, err
+		}
+		if key == KeyZone {
+			if _, err := zone.Load(value); err != nil {
+				return Meta{}, nil, err
+			}
+		}
+		*p = value
+	}
+	return m, rest, nil
+}
+
+// validateValue проверяет, что значение метаданных не пусто и не содержит разделителей записи.
+func validateValue(key, value string) error {
+	if strings.TrimSpace(value) == "" {
+		return parseerr.New(key, parseerr.KindFormat, "значение параметра %q не может быть пустым", key)
+	}
+	if strings.ContainsAny(value, fieldSeparator+segmentSeparator+valueSeparator+"\t\n") {
+		return parseerr.New(key, parseerr.KindFormat, "значение параметра %q содержит разделитель записи: %q", key, value)
+	}
+	return nil
+}
+
+// FromRecord возвращает метаданные записи журнала. У интервальной тренировки
+// это метаданные первого отрезка, где они указаны. Неверные параметры
+// пропускаются: запись проверяется при разборе.
+func FromRecord(record string) Meta {
+	for _, segment := range strings.Split(record, segmentSeparator) {
+		var m Meta
+		for _, f := range strings.Split(segment, fieldSeparator) {
+			key, value, _ := strings.Cut(f, valueSeparator)
+			if p := m.field(key); p != nil && *p == "" {
+				*p = value
+			}
+		}
+		if m != (Meta{}) {
+			return m
+		}
+	}
+	return Meta{}
+}
+
+// WithBatch добавляет к каждому отрезку записи record идентификатор партии
+// импорта batch, если у отрезка его ещё нет.
+func WithBatch(record, batch string) (string, error) {
+	if err := validateValue(KeyBatch, batch); err != nil {
+		return "", err
+	}
+	segments := strings.Split(record, segmentSeparator)
+	for i, segment := range segments {
+		if FromRecord(segment).Batch == "" {
+			segments[i] = segment + fieldSeparator + KeyBatch + valueSeparator + batch
+		}
+	}
+	return strings.Join(segments, segmentSeparator), nil
+}
+
+// Matches сообщает, совпадает ли source с источником, устройством или приложением.
+func (m Meta) Matches(source string) bool {
+	return source != "" && (m.Source == source || m.Device == source || m.App == source)
+}
+
+// Text возвращает строку отчёта о происхождении записи, например
+// "Данные: источник watch, устройство Forerunner 255, прошивка 20.26\n".
+// Для пустых метаданных возвращает пустую строку.
+func (m Meta) Text() string {
+	labels := map[string]string{
+		KeySource:   "источник",
+		KeyDevice:   "устройство",
+		KeyApp:      "приложение",
+		KeyFirmware: "прошивка",
+		KeyBatch:    "импорт",
+		KeyZone:     "пояс",
+	}
+
+	var parts []string
+	for _, key := range keys {
+		if v := *m.field(key); v != "" {
+			parts = append(parts, fmt.Sprintf("%s %s", labels[key], v))
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "Данные: " + strings.Join(parts, ", ") + "\n"
+}
+
+// Location возвращает часовой пояс записи или def, если пояс не указан или неизвестен.
+func (m Meta) Location(def *time.Location) *time.Location {
+	if m.Zone == "" {
+		return def
+	}
+	loc, err := zone.Load(m.Zone)
+	if err != nil {
+		return def
+	}
+	return loc
+}
@@ -0,0 +1,80 @@
+package meta
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type MetaTestSuite struct {
+	suite.Suite
+}
+
+func TestMetaSuite(t *testing.T) {
+	suite.Run(t, new(MetaTestSuite))
+}
+
+func (suite *MetaTestSuite) TestSplit() {
+	got, rest, err := Split([]string{"gain=120", "source=watch", "hr=150", "device=Forerunner 255", "firmware=20.26", "app=Connect", "batch=b1"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), Meta{Source: "watch", Device: "Forerunner 255", App: "Connect", Firmware: "20.26", Batch: "b1"}, got)
+	assert.Equal(suite.T(), []string{"gain=120", "hr=150"}, rest)
+
+	got, rest, err = Split(nil)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), got)
+	assert.Empty(suite.T(), rest)
+
+	for _, fields := range [][]string{
+		{"source="},
+		{"source= "},
+		{"source=watch", "source=phone"},
+		{"device=a=b"},
+	} {
+		_, _, err := Split(fields)
+		assert.Error(suite.T(), err, fields)
+	}
+}
+
+func (suite *MetaTestSuite) TestFromRecord() {
+	assert.Equal(suite.T(), Meta{Source: "watch", Batch: "b1"}, FromRecord("678,0h50m,source=watch,batch=b1"))
+	assert.Equal(suite.T(), Meta{App: "Strava"}, FromRecord("1200,Бег,5m;400,Ходьба,2m,app=Strava"))
+	assert.Empty(suite.T(), FromRecord("678,0h50m"))
+}
+
+func (suite *MetaTestSuite) TestWithBatch() {
+	got, err := WithBatch("678,0h50m", "b2")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "678,0h50m,batch=b2", got)
+
+	got, err = WithBatch("1200,Бег,5m,batch=b1;400,Ходьба,2m", "b2")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "1200,Бег,5m,batch=b1;400,Ходьба,2m,batch=b2", got)
+
+	_, err = WithBatch("678,0h50m", "b,2")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *MetaTestSuite) TestMatchesAndText() {
+	m := Meta{Source: "watch", Device: "Forerunner 255", Firmware: "20.26"}
+	assert.True(suite.T(), m.Matches("watch"))
+	assert.True(suite.T(), m.Matches("Forerunner 255"))
+	assert.False(suite.T(), m.Matches("20.26"))
+	assert.False(suite.T(), Meta{}.Matches(""))
+
+	assert.Equal(suite.T(), "Данные: источник watch, устройство Forerunner 255, прошивка 20.26\n", m.Text())
+	assert.Empty(suite.T(), Meta{}.Text())
+}
+
+func (suite *MetaTestSuite) TestZone() {
+	got, _, err := Split([]string{"tz=America/New_York"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "America/New_York", got.Location(time.UTC).String())
+	assert.Equal(suite.T(), time.UTC, Meta{}.Location(time.UTC))
+	assert.Equal(suite.T(), "Данные: пояс America/New_York\n", got.Text())
+
+	_, _, err = Split([]string{"tz=Mars/Olympus"})
+	assert.Error(suite.T(), err)
+}
@@ -0,0 +1,144 @@
+package nutrition
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Yandex-Practicum/tracker/internal/meta"
+	"github.com/Yandex-Practicum/tracker/internal/parseerr"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+)
+
+// Коэффициенты формулы Миффлина — Сан Жеора для основного обмена.
+const (
+	bmrWeightMultiplier = 10   // ккал на кг веса.
+	bmrHeightMultiplier = 6.25 // ккал на см роста.
+	bmrAgeMultiplier    = 5    // ккал на год возраста.
+	bmrMaleOffset       = 5    // поправка для мужчин, ккал.
+	bmrFemaleOffset     = -161 // поправка для женщин, ккал.
+	cmInM               = 100  // сантиметров в метре.
+)
+
+// Meal — приём пищи.
+type Meal struct {
+	Name     string
+	Calories float64 // ккал.
+	// Protein, Fat и Carbs — белки, жиры и углеводы, г; 0 — не указаны.
+	Protein float64
+	Fat     float64
+	Carbs   float64
+	// Meta — происхождение записи.
+	Meta meta.Meta
+}
+
+// ParseMeal разбирает запись приёма пищи вида "Завтрак,520" с необязательными
+// параметрами: белками "protein=30", жирами "fat=12" и углеводами "carbs=60"
+// в граммах, а также метаданными, например "app=FatSecret".
+func ParseMeal(record string) (Meal, error) {
+	fields := strings.Split(record, ",")
+	if len(fields) < 2 {
+		return Meal{}, parseerr.New("", parseerr.KindFormat, "неверный формат приёма пищи: ожидается \"название,ккал\"")
+	}
+
+	m := Meal{Name: strings.TrimSpace(fields[0])}
+	if m.Name == "" {
+		return Meal{}, parseerr.New("meal", parseerr.KindFormat, "название приёма пищи не может быть пустым")
+	}
+	calories, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return Meal{}, parseerr.New("kcal", parseerr.KindSyntax, "некорректная калорийность: %w", err)
+	}
+	if calories <= 0 {
+		return Meal{}, parseerr.New("kcal", parseerr.KindRange, "калорийность должна быть больше нуля: %.2f", calories)
+	}
+	m.Calories = calories
+
+	if m.Meta, fields, err = meta.Split(fields[2:]); err != nil {
+		return Meal{}, err
+	}
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return Meal{}, parseerr.New("", parseerr.KindFormat, "неверный параметр приёма пищи %q: ожидается \"ключ=значение\"", field)
+		}
+		if err := m.setMacro(key, value); err != nil {
+			return Meal{}, err
+		}
+	}
+	return m, nil
+}
+
+// setMacro задаёт количество макронутриента key в граммах.
+func (m *Meal) setMacro(key, value string) error {
+	var p *float64
+	switch key {
+	case "protein":
+		p = &m.Protein
+	case "fat":
+		p = &m.Fat
+	case "carbs":
+		p = &m.Carbs
+	default:
+		return parseerr.New(key, parseerr.KindUnknown, "неизвестный параметр приёма пищи: %q", key)
+	}
+	if *p != 0 {
+		return parseerr.New(key, parseerr.KindConflict, "параметр приёма пищи %q указан повторно", key)
+	}
+
+	grams, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return parseerr.New(key, parseerr.KindSyntax, "некорректное количество %q: %w", key, err)
+	}
+	if grams <= 0 {
+		return parseerr.New(key, parseerr.KindRange, "количество %q должно быть больше нуля: %.1f", key, grams)
+	}
+	*p = grams
+	return nil
+}
+
+// Info возвращает сводку приёма пищи, например
+// "Приём пищи: Завтрак, 520.00 ккал.\nСостав: белки 30.0 г, жиры 12.0 г, углеводы 60.0 г.\n".
+// Строка состава выводится, только если указан хотя бы один макронутриент.
+func (m Meal) Info() string {
+	info := fmt.Sprintf("Приём пищи: %s, %.2f ккал.\n", m.Name, m.Calories)
+	if macros := m.macrosText(); macros != "" {
+		info += "Состав: " + macros + ".\n"
+	}
+	return info + m.Meta.Text()
+}
+
+// macrosText возвращает указанные макронутриенты через запятую.
+func (m Meal) macrosText() string {
+	var parts []string
+	for _, macro := range []struct {
+		name  string
+		grams float64
+	}{{"белки", m.Protein}, {"жиры", m.Fat}, {"углеводы", m.Carbs}} {
+		if macro.grams > 0 {
+			parts = append(parts, fmt.Sprintf("%s %.1f г", macro.name, macro.grams))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// BMR возвращает основной обмен по формуле Миффлина — Сан Жеора, ккал в сутки:
+// вес weight в кг, рост height в м и возраст age в годах.
+func BMR(weight, height float64, age int, sex profile.Sex) (float64, error) {
+	if weight <= 0 || height <= 0 {
+		return 0, parseerr.New("", parseerr.KindRange, "вес и рост должны быть больше нуля: %.2f кг, %.2f м", weight, height)
+	}
+	if age <= 0 {
+		return 0, parseerr.New("birth_year", parseerr.KindRange, "возраст должен быть больше нуля: %d", age)
+	}
+
+	bmr := bmrWeightMultiplier*weight + bmrHeightMultiplier*height*cmInM - bmrAgeMultiplier*float64(age)
+	switch sex {
+	case profile.SexMale:
+		return bmr + bmrMaleOffset, nil
+	case profile.SexFemale:
+		return bmr + bmrFemaleOffset, nil
+	default:
+		return 0, parseerr.New("sex", parseerr.KindUnknown, "неизвестный пол: %q", sex)
+	}
+}
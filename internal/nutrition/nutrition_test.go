@@ -0,0 +1,72 @@
+package nutrition
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Yandex-Practicum/tracker/internal/meta"
+	"github.com/Yandex-Practicum/tracker/internal/parseerr"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type NutritionTestSuite struct {
+	suite.Suite
+}
+
+func TestNutritionSuite(t *testing.T) {
+	suite.Run(t, new(NutritionTestSuite))
+}
+
+func (suite *NutritionTestSuite) TestParseMeal() {
+	m, err := ParseMeal("Завтрак,520,protein=30,carbs=60,app=FatSecret")
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), Meal{Name: "Завтрак", Calories: 520, Protein: 30, Carbs: 60, Meta: meta.Meta{App: "FatSecret"}}, m)
+	assert.Equal(suite.T(), "Приём пищи: Завтрак, 520.00 ккал.\nСостав: белки 30.0 г, углеводы 60.0 г.\nДанные: приложение FatSecret\n", m.Info())
+
+	m, err = ParseMeal("Перекус,150.5")
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "Приём пищи: Перекус, 150.50 ккал.\n", m.Info())
+}
+
+func (suite *NutritionTestSuite) TestParseMealErrors() {
+	tests := []struct {
+		record string
+		kind   parseerr.Kind
+	}{
+		{"Завтрак", parseerr.KindFormat},
+		{" ,520", parseerr.KindFormat},
+		{"Завтрак,много", parseerr.KindSyntax},
+		{"Завтрак,0", parseerr.KindRange},
+		{"Завтрак,520,fat=-1", parseerr.KindRange},
+		{"Завтрак,520,fat=1,fat=2", parseerr.KindConflict},
+		{"Завтрак,520,sugar=10", parseerr.KindUnknown},
+		{"Завтрак,520,овсянка", parseerr.KindFormat},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.record, func() {
+			_, err := ParseMeal(tt.record)
+			var e *parseerr.Error
+			suite.Require().True(errors.As(err, &e), "ошибка: %v", err)
+			assert.Equal(suite.T(), tt.kind, e.Kind)
+		})
+	}
+}
+
+func (suite *NutritionTestSuite) TestBMR() {
+	bmr, err := BMR(80, 1.8, 36, profile.SexMale)
+	suite.Require().NoError(err)
+	assert.InDelta(suite.T(), 1750, bmr, 1e-9)
+
+	bmr, err = BMR(60, 1.65, 30, profile.SexFemale)
+	suite.Require().NoError(err)
+	assert.InDelta(suite.T(), 1320.25, bmr, 1e-9)
+
+	_, err = BMR(80, 1.8, 36, "")
+	assert.Error(suite.T(), err)
+	_, err = BMR(80, 1.8, 0, profile.SexMale)
+	assert.Error(suite.T(), err)
+	_, err = BMR(0, 1.8, 36, profile.SexMale)
+	assert.Error(suite.T(), err)
+}
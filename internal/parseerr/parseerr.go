@@ -0,0 +1,56 @@
+package parseerr
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// Kind — вид ошибки разбора.
+type Kind string
+
+// Виды ошибок разбора.
+const (
+	KindFormat   Kind = "format"   // неверное количество или порядок полей.
+	KindSyntax   Kind = "syntax"   // значение поля не удалось прочитать.
+	KindRange    Kind = "range"    // значение вне допустимого диапазона.
+	KindUnknown  Kind = "unknown"  // неизвестный тип или параметр.
+	KindConflict Kind = "conflict" // поля противоречат друг другу.
+)
+
+// Error — ошибка разбора или проверки поля записи.
+// Текст ошибки совпадает с текстом вложенной ошибки.
+type Error struct {
+	Field string // имя поля: steps, duration, type и т. п.; пусто для записи целиком.
+	Kind  Kind
+	Err   error
+}
+
+// New возвращает ошибку поля field вида kind с текстом по формату.
+func New(field string, kind Kind, format string, args ...any) *Error {
+	return &Error{Field: field, Kind: kind, Err: fmt.Errorf(format, args...)}
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Attr возвращает группу атрибутов "error" для slog: текст ошибки,
+// а также поле и вид, если в цепочке есть *Error.
+func Attr(err error) slog.Attr {
+	attrs := []any{slog.String("message", err.Error())}
+
+	var e *Error
+	if errors.As(err, &e) {
+		if e.Field != "" {
+			attrs = append(attrs, slog.String("field", e.Field))
+		}
+		attrs = append(attrs, slog.String("kind", string(e.Kind)))
+	}
+
+	return slog.Group("error", attrs...)
+}
@@ -0,0 +1,73 @@
+package parseerr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ParseErrTestSuite struct {
+	suite.Suite
+}
+
+func TestParseErrSuite(t *testing.T) {
+	suite.Run(t, new(ParseErrTestSuite))
+}
+
+func (suite *ParseErrTestSuite) TestError() {
+	err := New("steps", KindRange, "количество шагов должно быть больше нуля: %d", 0)
+	wrapped := fmt.Errorf("отрезок 2: %w", err)
+
+	assert.Equal(suite.T(), "количество шагов должно быть больше нуля: 0", err.Error())
+
+	var e *Error
+	assert.True(suite.T(), errors.As(wrapped, &e))
+	assert.Equal(suite.T(), "steps", e.Field)
+	assert.Equal(suite.T(), KindRange, e.Kind)
+}
+
+func (suite *ParseErrTestSuite) TestAttr() {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "ошибка поля",
+			err:  fmt.Errorf("отрезок 1: %w", New("duration", KindSyntax, "некорректная продолжительность")),
+			want: `error.message="отрезок 1: некорректная продолжительность" error.field=duration error.kind=syntax`,
+		},
+		{
+			name: "ошибка записи",
+			err:  New("", KindFormat, "неверный формат"),
+			want: `error.message="неверный формат" error.kind=format`,
+		},
+		{
+			name: "обычная ошибка",
+			err:  errors.New("сбой"),
+			want: `error.message=сбой`,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+				ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+					if a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.MessageKey {
+						return slog.Attr{}
+					}
+					return a
+				},
+			}))
+
+			logger.Info("", Attr(tt.err))
+			assert.Equal(suite.T(), tt.want+"\n", buf.String())
+		})
+	}
+}
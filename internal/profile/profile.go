@@ -0,0 +1,128 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/parseerr"
+	"github.com/Yandex-Practicum/tracker/internal/zone"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultStepGoal — дневная цель по шагам, если она не задана в профиле.
+const DefaultStepGoal = 10000
+
+// Sex — пол пользователя для расчёта основного обмена.
+type Sex string
+
+// Допустимые значения пола.
+const (
+	SexMale   Sex = "male"
+	SexFemale Sex = "female"
+)
+
+// minBirthYear — самый ранний допустимый год рождения.
+const minBirthYear = 1900
+
+// ErrNotFound возвращается, если профиль ещё не сохранён.
+var ErrNotFound = errors.New("профиль не задан")
+
+// Profile — параметры пользователя, нужные для расчётов.
+type Profile struct {
+	Weight float64 `yaml:"weight"` // вес, кг.
+	Height float64 `yaml:"height"` // рост, м.
+	// StepGoal — дневная цель по шагам; 0 означает DefaultStepGoal.
+	StepGoal int `yaml:"step_goal,omitempty"`
+	// Timezone — часовой пояс IANA, по которому записи делятся на дни;
+	// пусто — местный пояс системы.
+	Timezone string `yaml:"timezone,omitempty"`
+	// Sex и BirthYear нужны для расчёта основного обмена; пусто и 0 — не заданы.
+	Sex       Sex `yaml:"sex,omitempty"`
+	BirthYear int `yaml:"birth_year,omitempty"`
+}
+
+// Age возвращает возраст, исполняющийся в году момента t, или 0, если год рождения не задан.
+// Точная дата рождения не хранится, поэтому возраст меняется 1 января.
+func (p Profile) Age(t time.Time) int {
+	if p.BirthYear == 0 {
+		return 0
+	}
+	return t.Year() - p.BirthYear
+}
+
+// Location возвращает часовой пояс пользователя или местный, если пояс не задан или неизвестен.
+func (p Profile) Location() *time.Location {
+	loc, err := zone.Load(p.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// Goal возвращает дневную цель по шагам.
+func (p Profile) Goal() int {
+	if p.StepGoal == 0 {
+		return DefaultStepGoal
+	}
+	return p.StepGoal
+}
+
+// Validate проверяет, что вес и рост положительны, цель по шагам не отрицательна,
+// часовой пояс известен, а пол и год рождения, если заданы, допустимы.
+func (p Profile) Validate() error {
+	if _, err := zone.Load(p.Timezone); err != nil {
+		return err
+	}
+	switch {
+	case p.Weight <= 0:
+		return parseerr.New("weight", parseerr.KindRange, "вес должен быть больше нуля: %.2f", p.Weight)
+	case p.Height <= 0:
+		return parseerr.New("height", parseerr.KindRange, "рост должен быть больше нуля: %.2f", p.Height)
+	case p.StepGoal < 0:
+		return parseerr.New("step_goal", parseerr.KindRange, "цель по шагам не может быть отрицательной: %d", p.StepGoal)
+	case p.Sex != "" && p.Sex != SexMale && p.Sex != SexFemale:
+		return parseerr.New("sex", parseerr.KindUnknown, "неизвестный пол: %q, ожидается %q или %q", p.Sex, SexMale, SexFemale)
+	case p.BirthYear != 0 && (p.BirthYear < minBirthYear || p.BirthYear > time.Now().Year()):
+		return parseerr.New("birth_year", parseerr.KindRange, "год рождения должен быть от %d до текущего: %d", minBirthYear, p.BirthYear)
+	}
+	return nil
+}
+
+// Load читает профиль из файла YAML. Если файла нет, возвращает ErrNotFound.
+func Load(path string) (Profile, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Profile{}, ErrNotFound
+	}
+	if err != nil {
+		return Profile{}, fmt.Errorf("не удалось прочитать профиль: %w", err)
+	}
+
+	var p Profile
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return Profile{}, fmt.Errorf("некорректный профиль %s: %w", path, err)
+	}
+	if err := p.Validate(); err != nil {
+		return Profile{}, err
+	}
+	return p, nil
+}
+
+// Save проверяет профиль и записывает его в файл YAML.
+func Save(path string, p Profile) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("не удалось сохранить профиль: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProfileTestSuite struct {
+	suite.Suite
+}
+
+func TestProfileSuite(t *testing.T) {
+	suite.Run(t, new(ProfileTestSuite))
+}
+
+func (suite *ProfileTestSuite) TestSaveLoad() {
+	path := filepath.Join(suite.T().TempDir(), "profile.yaml")
+
+	_, err := Load(path)
+	assert.ErrorIs(suite.T(), err, ErrNotFound)
+
+	want := Profile{Weight: 84.6, Height: 1.87}
+	suite.Require().NoError(Save(path, want))
+
+	got, err := Load(path)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), want, got)
+}
+
+func (suite *ProfileTestSuite) TestLocation() {
+	assert.Equal(suite.T(), time.Local, Profile{}.Location())
+	assert.Equal(suite.T(), "Europe/Berlin", Profile{Timezone: "Europe/Berlin"}.Location().String())
+}
+
+func (suite *ProfileTestSuite) TestAge() {
+	at := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	assert.Equal(suite.T(), 36, Profile{BirthYear: 1990}.Age(at))
+	assert.Zero(suite.T(), Profile{}.Age(at))
+}
+
+func (suite *ProfileTestSuite) TestValidate() {
+	path := filepath.Join(suite.T().TempDir(), "profile.yaml")
+
+	assert.Error(suite.T(), Save(path, Profile{Weight: 0, Height: 1.8}))
+	assert.Error(suite.T(), Save(path, Profile{Weight: 80, Height: -1.8}))
+
+	assert.Error(suite.T(), Save(path, Profile{Weight: 80, Height: 1.8, Timezone: "Mars/Olympus"}))
+	assert.Error(suite.T(), Save(path, Profile{Weight: 80, Height: 1.8, Sex: "m"}))
+	assert.Error(suite.T(), Save(path, Profile{Weight: 80, Height: 1.8, BirthYear: 1850}))
+	assert.NoError(suite.T(), Save(path, Profile{Weight: 80, Height: 1.8, Sex: SexFemale, BirthYear: 1990}))
+
+	suite.Require().NoError(os.WriteFile(path, []byte("weight: 80\n"), 0o600))
+	_, err := Load(path)
+	assert.Error(suite.T(), err)
+}
@@ -0,0 +1,148 @@
+package recovery
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/meta"
+	"github.com/Yandex-Practicum/tracker/internal/parseerr"
+)
+
+// Оценка качества сна по шкале от MinQuality до MaxQuality.
+const (
+	MinQuality = 1
+	MaxQuality = 5
+)
+
+// clockLayout — формат времени отхода ко сну и пробуждения.
+const clockLayout = "15:04"
+
+// Sleep — ночной сон. Ночь относится ко дню пробуждения.
+type Sleep struct {
+	Bedtime time.Time
+	Wake    time.Time
+	// Quality — оценка качества сна; 0 — не указана.
+	Quality int
+	// Meta — происхождение записи.
+	Meta meta.Meta
+}
+
+// ParseSleep разбирает запись сна вида "23:15,07:05" с необязательными
+// параметрами: оценкой качества "quality=4" и метаданными, например
+// "source=watch". Время пробуждения относится к календарному дню day,
+// а отход ко сну — к тому же дню, если он раньше пробуждения, иначе к предыдущему.
+// Продолжительность учитывает переходы на летнее и зимнее время в поясе day.
+func ParseSleep(record string, day time.Time) (Sleep, error) {
+	fields := strings.Split(record, ",")
+	if len(fields) < 2 {
+		return Sleep{}, parseerr.New("", parseerr.KindFormat, "неверный формат сна: ожидается \"отход ко сну,пробуждение\"")
+	}
+
+	bed, err := parseClock("bedtime", "время отхода ко сну", fields[0])
+	if err != nil {
+		return Sleep{}, err
+	}
+	wake, err := parseClock("wake", "время пробуждения", fields[1])
+	if err != nil {
+		return Sleep{}, err
+	}
+
+	m, rest, err := meta.Split(fields[2:])
+	if err != nil {
+		return Sleep{}, err
+	}
+	s := Sleep{Meta: m}
+	for _, field := range rest {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return Sleep{}, parseerr.New("", parseerr.KindFormat, "неверный параметр сна %q: ожидается \"ключ=значение\"", field)
+		}
+		if key != "quality" {
+			return Sleep{}, parseerr.New(key, parseerr.KindUnknown, "неизвестный параметр сна: %q", key)
+		}
+		if s.Quality != 0 {
+			return Sleep{}, parseerr.New(key, parseerr.KindConflict, "параметр сна %q указан повторно", key)
+		}
+		if s.Quality, err = parseQuality(value); err != nil {
+			return Sleep{}, err
+		}
+	}
+
+	y, mon, d := day.Date()
+	loc := day.Location()
+	s.Wake = time.Date(y, mon, d, wake.Hour(), wake.Minute(), 0, 0, loc)
+	s.Bedtime = time.Date(y, mon, d, bed.Hour(), bed.Minute(), 0, 0, loc)
+	if !s.Bedtime.Before(s.Wake) {
+		s.Bedtime = time.Date(y, mon, d-1, bed.Hour(), bed.Minute(), 0, 0, loc)
+	}
+	return s, nil
+}
+
+// parseClock разбирает время суток поля field в формате "15:04".
+func parseClock(field, name, value string) (time.Time, error) {
+	t, err := time.Parse(clockLayout, value)
+	if err != nil {
+		return time.Time{}, parseerr.New(field, parseerr.KindSyntax, "некорректное %s %q: ожидается %q", name, value, clockLayout)
+	}
+	return t, nil
+}
+
+// parseQuality разбирает оценку качества сна.
+func parseQuality(value string) (int, error) {
+	q, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, parseerr.New("quality", parseerr.KindSyntax, "некорректная оценка качества сна: %w", err)
+	}
+	if q < MinQuality || q > MaxQuality {
+		return 0, parseerr.New("quality", parseerr.KindRange, "оценка качества сна должна быть от %d до %d: %d", MinQuality, MaxQuality, q)
+	}
+	return q, nil
+}
+
+// Duration возвращает продолжительность сна.
+func (s Sleep) Duration() time.Duration {
+	return s.Wake.Sub(s.Bedtime)
+}
+
+// Info возвращает сводку сна, например
+// "Сон: 23:15 — 07:05, 7.83 ч.\nКачество сна: 4 из 5.\n".
+// Метаданные записи, если есть, выводятся последней строкой.
+func (s Sleep) Info() string {
+	info := fmt.Sprintf("Сон: %s — %s, %.2f ч.\n", s.Bedtime.Format(clockLayout), s.Wake.Format(clockLayout), s.Duration().Hours())
+	if s.Quality > 0 {
+		info += fmt.Sprintf("Качество сна: %d из %d.\n", s.Quality, MaxQuality)
+	}
+	return info + s.Meta.Text()
+}
+
+// Rest — запланированный день отдыха. В отличие от пропущенного дня,
+// он не прерывает серию выполненных целей.
+type Rest struct {
+	// Note — причина отдыха, например "после полумарафона".
+	Note string
+	// Meta — происхождение записи.
+	Meta meta.Meta
+}
+
+// ParseRest разбирает запись дня отдыха: непустое пояснение и необязательные
+// метаданные, например "после полумарафона,batch=b1". Запятые без метаданных
+// остаются частью пояснения.
+func ParseRest(record string) (Rest, error) {
+	m, fields, err := meta.Split(strings.Split(record, ","))
+	if err != nil {
+		return Rest{}, err
+	}
+	note := strings.TrimSpace(strings.Join(fields, ","))
+	if note == "" {
+		return Rest{}, parseerr.New("note", parseerr.KindFormat, "укажите причину дня отдыха")
+	}
+	return Rest{Note: note, Meta: m}, nil
+}
+
+// Info возвращает сводку дня отдыха, например "День отдыха: после полумарафона.\n".
+// Метаданные записи, если есть, выводятся последней строкой.
+func (r Rest) Info() string {
+	return fmt.Sprintf("День отдыха: %s.\n", r.Note) + r.Meta.Text()
+}
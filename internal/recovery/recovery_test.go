@@ -0,0 +1,95 @@
+package recovery
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/meta"
+	"github.com/Yandex-Practicum/tracker/internal/parseerr"
+	"github.com/Yandex-Practicum/tracker/internal/zone"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type RecoveryTestSuite struct {
+	suite.Suite
+}
+
+func TestRecoverySuite(t *testing.T) {
+	suite.Run(t, new(RecoveryTestSuite))
+}
+
+func (suite *RecoveryTestSuite) TestParseSleep() {
+	day := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+
+	s, err := ParseSleep("23:15,07:05,quality=4,source=watch", day)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), time.Date(2026, 10, 18, 23, 15, 0, 0, time.UTC), s.Bedtime)
+	assert.Equal(suite.T(), time.Date(2026, 10, 19, 7, 5, 0, 0, time.UTC), s.Wake)
+	assert.Equal(suite.T(), 7*time.Hour+50*time.Minute, s.Duration())
+	assert.Equal(suite.T(), 4, s.Quality)
+	assert.Equal(suite.T(), meta.Meta{Source: "watch"}, s.Meta)
+	assert.Equal(suite.T(), "Сон: 23:15 — 07:05, 7.83 ч.\nКачество сна: 4 из 5.\nДанные: источник watch\n", s.Info())
+
+	// Отход ко сну после полуночи относится к дню пробуждения.
+	s, err = ParseSleep("01:30,09:00", day)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 7*time.Hour+30*time.Minute, s.Duration())
+	assert.Zero(suite.T(), s.Quality)
+	assert.Equal(suite.T(), "Сон: 01:30 — 09:00, 7.50 ч.\n", s.Info())
+}
+
+func (suite *RecoveryTestSuite) TestParseSleepDST() {
+	berlin, err := zone.Load("Europe/Berlin")
+	suite.Require().NoError(err)
+
+	// В ночь на 25 октября 2026 года часы в Берлине переводятся назад: ночь на час длиннее.
+	s, err := ParseSleep("23:00,07:00", zone.Date(2026, 10, 25, berlin))
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 9*time.Hour, s.Duration())
+}
+
+func (suite *RecoveryTestSuite) TestParseSleepErrors() {
+	tests := []struct {
+		record string
+		kind   parseerr.Kind
+	}{
+		{"23:15", parseerr.KindFormat},
+		{"23.15,07:05", parseerr.KindSyntax},
+		{"23:15,7h", parseerr.KindSyntax},
+		{"23:15,07:05,quality=6", parseerr.KindRange},
+		{"23:15,07:05,quality=хорошо", parseerr.KindSyntax},
+		{"23:15,07:05,quality=3,quality=4", parseerr.KindConflict},
+		{"23:15,07:05,dream=1", parseerr.KindUnknown},
+		{"23:15,07:05,глубокий", parseerr.KindFormat},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.record, func() {
+			_, err := ParseSleep(tt.record, time.Now())
+			var e *parseerr.Error
+			suite.Require().True(errors.As(err, &e), "ошибка: %v", err)
+			assert.Equal(suite.T(), tt.kind, e.Kind)
+		})
+	}
+}
+
+func (suite *RecoveryTestSuite) TestParseRest() {
+	r, err := ParseRest(" после полумарафона ")
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "День отдыха: после полумарафона.\n", r.Info())
+
+	_, err = ParseRest(" ")
+	assert.Error(suite.T(), err)
+
+	// Метаданные отделяются от пояснения, запятые в пояснении сохраняются.
+	r, err = ParseRest("после забега, тяжёлого,batch=b1")
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), Rest{Note: "после забега, тяжёлого", Meta: meta.Meta{Batch: "b1"}}, r)
+	assert.Equal(suite.T(), "День отдыха: после забега, тяжёлого.\nДанные: импорт b1\n", r.Info())
+
+	_, err = ParseRest("batch=b1")
+	assert.Error(suite.T(), err)
+	_, err = ParseRest("отдых,batch=b1,batch=b2")
+	assert.Error(suite.T(), err)
+}
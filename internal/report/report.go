@@ -0,0 +1,445 @@
+package report
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/body"
+	"github.com/Yandex-Practicum/tracker/internal/config"
+	"github.com/Yandex-Practicum/tracker/internal/daysteps"
+	"github.com/Yandex-Practicum/tracker/internal/journal"
+	"github.com/Yandex-Practicum/tracker/internal/meta"
+	"github.com/Yandex-Practicum/tracker/internal/nutrition"
+	"github.com/Yandex-Practicum/tracker/internal/parseerr"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/recovery"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/Yandex-Practicum/tracker/internal/stepmerge"
+	"github.com/Yandex-Practicum/tracker/internal/zone"
+)
+
+// dateLayout — формат даты в отчётах.
+const dateLayout = "02.01.2006"
+
+// Training — рассчитанная тренировка из журнала.
+type Training struct {
+	// Time — начало тренировки в поясе, по которому она отнесена к дню.
+	Time   time.Time
+	Record string
+	Result spentcalories.SessionResult
+}
+
+// Pace возвращает темп, раскладку и прогнозы по беговым отрезкам тренировки,
+// как spentcalories.PaceInfo; для тренировок без бега ok ложно.
+func (t Training) Pace() (pace spentcalories.Pace, ok bool) {
+	pace, err := t.Result.RunningPace(nil)
+	return pace, err == nil
+}
+
+// Day — показатели одного дня.
+type Day struct {
+	Date      time.Time
+	Steps     int
+	Distance  float64 // км, по пакетам дневной активности.
+	Calories  float64 // ккал, по пакетам дневной активности.
+	Trainings []Training
+	// Hourly — шаги по часам дня: шаги пакета распределяются
+	// по часам пропорционально продолжительности.
+	Hourly [24]int
+	// Sources — шаги дня по источникам данных; шаги пакетов без источника
+	// учитываются под пустым именем.
+	Sources map[string]int
+	// Duplicates — шаги пересекающихся пакетов, не учтённые повторно.
+	Duplicates int
+	// Sleep — ночи, закончившиеся пробуждением в этот день.
+	Sleep []recovery.Sleep
+	// Rest — запланированный отдых; nil, если день не отмечен как день отдыха.
+	Rest *recovery.Rest
+	// Meals — приёмы пищи дня.
+	Meals []nutrition.Meal
+}
+
+// Intake возвращает калорийность приёмов пищи дня, ккал.
+func (d Day) Intake() float64 {
+	var total float64
+	for _, m := range d.Meals {
+		total += m.Calories
+	}
+	return total
+}
+
+// SleepDuration возвращает суммарную продолжительность сна дня.
+func (d Day) SleepDuration() time.Duration {
+	var total time.Duration
+	for _, s := range d.Sleep {
+		total += s.Duration()
+	}
+	return total
+}
+
+// SleepQuality возвращает среднюю оценку качества сна дня или 0, если оценок нет.
+func (d Day) SleepQuality() float64 {
+	sum, n := 0, 0
+	for _, s := range d.Sleep {
+		if s.Quality > 0 {
+			sum += s.Quality
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return float64(sum) / float64(n)
+}
+
+// Totals — суммарные показатели за период.
+type Totals struct {
+	Steps            int
+	Distance         float64 // км.
+	Calories         float64 // ккал.
+	Trainings        int
+	TrainingDistance float64 // км.
+	TrainingCalories float64 // ккал.
+
+	SleepDays int           // дни с записанным сном.
+	Sleep     time.Duration // суммарная продолжительность сна.
+	RestDays  int           // запланированные дни отдыха.
+	Intake    float64       // калорийность приёмов пищи, ккал.
+}
+
+// Report — показатели по дням за период [From, To).
+type Report struct {
+	From, To time.Time
+	Days     []Day
+	// Skipped — число записей, которые не удалось рассчитать.
+	Skipped int
+}
+
+// Builder строит отчёты по записям журнала.
+type Builder struct {
+	steps     daysteps.Calculator
+	trainings spentcalories.Calculator
+	logger    *slog.Logger
+	body      body.History
+	merger    stepmerge.Merger
+}
+
+// NewBuilder возвращает построитель отчётов с коэффициентами cfg.
+// Ошибки отдельных записей пишутся в журнал logger, при nil — в slog.Default().
+func NewBuilder(cfg config.Config, logger *slog.Logger) Builder {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return Builder{
+		steps:     daysteps.NewCalculator(cfg).WithLogger(logger),
+		trainings: spentcalories.NewCalculator(cfg).WithLogger(logger),
+		logger:    logger,
+		merger:    stepmerge.New(cfg.SourcePriority),
+	}
+}
+
+// WithBody возвращает копию построителя, рассчитывающую каждую запись
+// с весом и ростом из истории измерений h на момент записи.
+func (b Builder) WithBody(h body.History) Builder {
+	b.body = h
+	return b
+}
+
+// Build возвращает отчёт по записям entries за период [from, to).
+// Дни отсчитываются в часовом поясе from с учётом переходов на летнее
+// и зимнее время, а запись с параметром tz относится к дню по своему
+// поясу: шаги путешественника не переходят в соседний день. Отчёт содержит
+// все дни периода, в том числе без активности. Сон относится ко дню
+// пробуждения. Вес и рост берутся из p,
+// если построитель не получил историю измерений в WithBody. Пересекающиеся
+// пакеты дневной активности объединяются без двойного учёта шагов, в том числе
+// через полночь; пакеты, начатые вне периода, при этом не учитываются.
+func (b Builder) Build(entries []journal.Entry, p profile.Profile, from, to time.Time) Report {
+	loc := from.Location()
+	r := Report{From: from, To: to}
+
+	index := make(map[time.Time]int)
+	for d := zone.StartOfDay(from, loc); d.Before(to); d = nextDay(d) {
+		index[d] = len(r.Days)
+		r.Days = append(r.Days, Day{Date: d})
+	}
+
+	var packets []packet
+	for _, e := range entries {
+		local := e.Time.In(meta.FromRecord(e.Record).Location(loc))
+		y, m, d := local.Date()
+		i, ok := index[zone.Date(y, m, d, loc)]
+		if !ok {
+			continue
+		}
+		day := &r.Days[i]
+		cur := b.body.At(e.Time, p)
+
+		switch e.Kind {
+		case journal.KindSteps:
+			s, err := b.steps.Summarize(e.Record, cur.Weight, cur.Height)
+			if err != nil {
+				b.skip(e, err)
+				r.Skipped++
+				continue
+			}
+			packets = append(packets, packet{day: i, start: local, summary: s})
+		case journal.KindTraining:
+			res, err := b.trainings.Summarize(e.Record, cur.Weight, cur.Height)
+			if err != nil {
+				b.skip(e, err)
+				r.Skipped++
+				continue
+			}
+			day.Trainings = append(day.Trainings, Training{Time: local, Record: e.Record, Result: res})
+		case journal.KindSleep:
+			sl, err := recovery.ParseSleep(e.Record, local)
+			if err != nil {
+				b.skip(e, err)
+				r.Skipped++
+				continue
+			}
+			day.Sleep = append(day.Sleep, sl)
+		case journal.KindRest:
+			rest, err := recovery.ParseRest(e.Record)
+			if err != nil {
+				b.skip(e, err)
+				r.Skipped++
+				continue
+			}
+			day.Rest = &rest
+		case journal.KindFood:
+			meal, err := nutrition.ParseMeal(e.Record)
+			if err != nil {
+				b.skip(e, err)
+				r.Skipped++
+				continue
+			}
+			day.Meals = append(day.Meals, meal)
+		}
+	}
+
+	b.addSteps(r.Days, packets)
+
+	return r
+}
+
+// nextDay возвращает начало дня, следующего за днём, начатым в d.
+func nextDay(d time.Time) time.Time {
+	y, m, dd := d.Date()
+	return zone.Date(y, m, dd+1, d.Location())
+}
+
+// packet — рассчитанный пакет дневной активности.
+type packet struct {
+	day     int       // индекс дня отчёта, к которому относится пакет.
+	start   time.Time // в часовом поясе записи.
+	summary daysteps.Summary
+}
+
+// addSteps объединяет пакеты всех дней отчёта и добавляет их шаги, дистанцию
+// и калории к дням. Пакеты объединяются вместе, а не по дням, чтобы пакеты,
+// пересекающиеся через полночь, не учитывались дважды; отрезок относится
+// к дню своего пакета. Дистанция и калории отрезка берутся из его пакета
+// пропорционально шагам.
+func (b Builder) addSteps(days []Day, packets []packet) {
+	if len(packets) == 0 {
+		return
+	}
+
+	in := make([]stepmerge.Packet, 0, len(packets))
+	for _, p := range packets {
+		in = append(in, stepmerge.Packet{Start: p.start, Duration: p.summary.Duration, Steps: p.summary.Steps, Source: p.summary.Meta.Source})
+	}
+	merged := b.merger.Merge(in)
+
+	for _, piece := range merged.Pieces {
+		p := packets[piece.Packet]
+		day := &days[p.day]
+		share := float64(piece.Steps) / float64(p.summary.Steps)
+		day.Steps += piece.Steps
+		day.Distance += p.summary.Distance * share
+		day.Calories += p.summary.Calories * share
+		// Часы отсчитываются по часовому поясу пакета, из которого взят отрезок.
+		if start := piece.Start.In(p.start.Location()); sameDay(start, p.start) {
+			spread(&day.Hourly, start, piece.End.Sub(piece.Start), piece.Steps)
+		} else {
+			// Отрезок пакета, начатого накануне полуночи, как и в spread, относится к последнему часу дня.
+			day.Hourly[len(day.Hourly)-1] += piece.Steps
+		}
+		if day.Sources == nil {
+			day.Sources = make(map[string]int)
+		}
+		day.Sources[piece.Source] += piece.Steps
+	}
+
+	// Повторные шаги дня — шаги его пакетов, не вошедшие в итог дня.
+	for _, p := range packets {
+		days[p.day].Duplicates += p.summary.Steps
+	}
+	for i := range days {
+		days[i].Duplicates -= days[i].Steps
+	}
+}
+
+// skip пишет в журнал ошибку расчёта записи.
+func (b Builder) skip(e journal.Entry, err error) {
+	b.logger.Warn("запись журнала пропущена",
+		slog.Int("line", e.Line), slog.String("kind", string(e.Kind)), slog.String("record", e.Record), parseerr.Attr(err))
+}
+
+// spread распределяет steps шагов пакета, начатого в start, по часам
+// пропорционально продолжительности. Часть пакета после полуночи
+// относится к последнему часу дня. Часы берутся по местному времени start:
+// при переходе на зимнее время повторённый час учитывается дважды в одной
+// ячейке, при переходе на летнее пропущенный час остаётся пустым.
+func spread(hourly *[24]int, start time.Time, duration time.Duration, steps int) {
+	end := start.Add(duration)
+	assigned := 0
+	for t := start; t.Before(end); {
+		// Следующий час отсчитывается по прошедшему времени, а не через time.Date:
+		// так повторённый при переходе час не пропускается.
+		next := t.Add(time.Hour - time.Duration(t.Minute())*time.Minute -
+			time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+		if next.After(end) {
+			next = end
+		}
+
+		hour := t.Hour()
+		if !sameDay(t, start) {
+			hour = len(hourly) - 1
+		}
+		// Округляем нарастающим итогом, чтобы сумма по часам совпадала с шагами пакета.
+		total := int(math.Round(float64(steps) * float64(next.Sub(start)) / float64(duration)))
+		hourly[hour] += total - assigned
+		assigned = total
+		t = next
+	}
+}
+
+// sameDay сообщает, приходятся ли a и b на один календарный день.
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// noSource — название источника в отчёте для пакетов без источника.
+const noSource = "без источника"
+
+// sourcesText возвращает шаги по источникам в порядке названий, например "phone 2000, watch 6000".
+func sourcesText(sources map[string]int) string {
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		label := name
+		if label == "" {
+			label = noSource
+		}
+		parts = append(parts, fmt.Sprintf("%s %d", label, sources[name]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Totals возвращает суммарные показатели за период.
+func (r Report) Totals() Totals {
+	var t Totals
+	for _, d := range r.Days {
+		t.Steps += d.Steps
+		t.Distance += d.Distance
+		t.Calories += d.Calories
+		for _, tr := range d.Trainings {
+			t.Trainings++
+			t.TrainingDistance += tr.Result.Distance
+			t.TrainingCalories += tr.Result.Calories
+		}
+		if len(d.Sleep) > 0 {
+			t.SleepDays++
+			t.Sleep += d.SleepDuration()
+		}
+		if d.Rest != nil {
+			t.RestDays++
+		}
+		t.Intake += d.Intake()
+	}
+	return t
+}
+
+// averageHours возвращает среднюю продолжительность total за n дней в часах или 0 при n = 0.
+func averageHours(total time.Duration, n int) float64 {
+	if n == 0 {
+		return 0
+	}
+	return total.Hours() / float64(n)
+}
+
+// Text возвращает отчёт в текстовом виде.
+func (r Report) Text() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Отчёт за %s — %s\n", r.From.Format(dateLayout), r.To.Add(-time.Nanosecond).Format(dateLayout))
+
+	for _, d := range r.Days {
+		fmt.Fprintf(&sb, "%s: шагов %d, %.2f км, %.2f ккал", d.Date.Format(dateLayout), d.Steps, d.Distance, d.Calories)
+		if len(d.Trainings) > 0 {
+			var (
+				dist, cal float64
+				paces     []string
+			)
+			for _, tr := range d.Trainings {
+				dist += tr.Result.Distance
+				cal += tr.Result.Calories
+				if p, ok := tr.Pace(); ok {
+					paces = append(paces, p.Clock())
+				}
+			}
+			fmt.Fprintf(&sb, "; тренировок %d: %.2f км, %.2f ккал", len(d.Trainings), dist, cal)
+			if len(paces) > 0 {
+				fmt.Fprintf(&sb, "; темп бега %s мин/км", strings.Join(paces, ", "))
+			}
+		}
+		if d.Duplicates > 0 {
+			fmt.Fprintf(&sb, "; повторных шагов не учтено %d", d.Duplicates)
+			if len(d.Sources) > 0 {
+				fmt.Fprintf(&sb, " (%s)", sourcesText(d.Sources))
+			}
+		}
+		if len(d.Sleep) > 0 {
+			fmt.Fprintf(&sb, "; сон %.2f ч.", d.SleepDuration().Hours())
+			if q := d.SleepQuality(); q > 0 {
+				fmt.Fprintf(&sb, ", качество %.1f из %d", q, recovery.MaxQuality)
+			}
+		}
+		if d.Rest != nil {
+			fmt.Fprintf(&sb, "; день отдыха: %s", d.Rest.Note)
+		}
+		if len(d.Meals) > 0 {
+			fmt.Fprintf(&sb, "; съедено %.2f ккал", d.Intake())
+		}
+		sb.WriteString("\n")
+	}
+
+	t := r.Totals()
+	fmt.Fprintf(&sb, "Итого: шагов %d, %.2f км, %.2f ккал; тренировок %d: %.2f км, %.2f ккал\n",
+		t.Steps, t.Distance, t.Calories, t.Trainings, t.TrainingDistance, t.TrainingCalories)
+	if t.SleepDays > 0 || t.RestDays > 0 {
+		fmt.Fprintf(&sb, "Восстановление: сон в среднем %.2f ч. в сутки по %d дн.; дней отдыха %d\n",
+			averageHours(t.Sleep, t.SleepDays), t.SleepDays, t.RestDays)
+	}
+	if t.Intake > 0 {
+		fmt.Fprintf(&sb, "Питание: съедено %.2f ккал\n", t.Intake)
+	}
+	if r.Skipped > 0 {
+		fmt.Fprintf(&sb, "Пропущено записей с ошибками: %d\n", r.Skipped)
+	}
+
+	return sb.String()
+}
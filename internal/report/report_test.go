@@ -0,0 +1,260 @@
+package report
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/body"
+	"github.com/Yandex-Practicum/tracker/internal/config"
+	"github.com/Yandex-Practicum/tracker/internal/journal"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/zone"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReportTestSuite struct {
+	suite.Suite
+}
+
+func TestReportSuite(t *testing.T) {
+	suite.Run(t, new(ReportTestSuite))
+}
+
+func day(d, h int) time.Time {
+	return time.Date(2026, 10, d, h, 0, 0, 0, time.UTC)
+}
+
+func (suite *ReportTestSuite) TestBuild() {
+	entries := []journal.Entry{
+		{Time: day(11, 10), Kind: journal.KindSteps, Record: "6000,1h00m"},
+		{Time: day(12, 8), Kind: journal.KindSteps, Record: "6000,1h00m", Line: 2},
+		{Time: day(12, 9), Kind: journal.KindSteps, Record: "3000,30m", Line: 3},
+		{Time: day(12, 18), Kind: journal.KindTraining, Record: "6000,Бег,1h00m", Line: 4},
+		{Time: day(13, 18), Kind: journal.KindTraining, Record: "6000,Плавание,1h00m", Line: 5},
+		{Time: day(15, 0), Kind: journal.KindSteps, Record: "6000,1h00m"},
+	}
+
+	var logs bytes.Buffer
+	b := NewBuilder(config.Default(), slog.New(slog.NewTextHandler(&logs, nil)))
+	r := b.Build(entries, profile.Profile{Weight: 75, Height: 1.75}, day(12, 0), day(15, 0))
+
+	suite.Require().Len(r.Days, 3)
+	assert.Equal(suite.T(), day(12, 0), r.Days[0].Date)
+	assert.Equal(suite.T(), 9000, r.Days[0].Steps)
+	assert.InDelta(suite.T(), 5.85, r.Days[0].Distance, 1e-9)
+	assert.InDelta(suite.T(), 265.78, r.Days[0].Calories, 0.01)
+	suite.Require().Len(r.Days[0].Trainings, 1)
+	assert.InDelta(suite.T(), 354.375, r.Days[0].Trainings[0].Result.Calories, 0.001)
+	assert.Empty(suite.T(), r.Days[1].Trainings)
+	assert.Equal(suite.T(), 1, r.Skipped)
+	assert.Contains(suite.T(), logs.String(), "line=5")
+
+	assert.Equal(suite.T(), 6000, r.Days[0].Hourly[8])
+	assert.Equal(suite.T(), 3000, r.Days[0].Hourly[9])
+
+	totals := r.Totals()
+	assert.Equal(suite.T(), 9000, totals.Steps)
+	assert.Equal(suite.T(), 1, totals.Trainings)
+
+	assert.Equal(suite.T(), "Отчёт за 12.10.2026 — 14.10.2026\n"+
+		"12.10.2026: шагов 9000, 5.85 км, 265.78 ккал; тренировок 1: 4.72 км, 354.38 ккал; темп бега 12:42 мин/км\n"+
+		"13.10.2026: шагов 0, 0.00 км, 0.00 ккал\n"+
+		"14.10.2026: шагов 0, 0.00 км, 0.00 ккал\n"+
+		"Итого: шагов 9000, 5.85 км, 265.78 ккал; тренировок 1: 4.72 км, 354.38 ккал\n"+
+		"Пропущено записей с ошибками: 1\n", r.Text())
+}
+
+func (suite *ReportTestSuite) TestBuildWithBody() {
+	entries := []journal.Entry{
+		{Time: day(12, 18), Kind: journal.KindTraining, Record: "6000,Бег,1h00m"},
+		{Time: day(13, 18), Kind: journal.KindTraining, Record: "6000,Бег,1h00m"},
+	}
+	h := body.History{{Date: day(13, 0), Weight: 60}}
+
+	r := NewBuilder(config.Default(), nil).WithBody(h).
+		Build(entries, profile.Profile{Weight: 75, Height: 1.75}, day(12, 0), day(14, 0))
+	// До первого измерения действует оно само, поэтому вес обоих дней — 60 кг.
+	assert.InDelta(suite.T(), 283.5, r.Days[0].Trainings[0].Result.Calories, 0.001)
+	assert.InDelta(suite.T(), 283.5, r.Days[1].Trainings[0].Result.Calories, 0.001)
+
+	h = body.History{{Date: day(12, 0), Weight: 75}, {Date: day(13, 0), Weight: 60}}
+	r = NewBuilder(config.Default(), nil).WithBody(h).
+		Build(entries, profile.Profile{Weight: 90, Height: 1.75}, day(12, 0), day(14, 0))
+	assert.InDelta(suite.T(), 354.375, r.Days[0].Trainings[0].Result.Calories, 0.001)
+	assert.InDelta(suite.T(), 283.5, r.Days[1].Trainings[0].Result.Calories, 0.001)
+}
+
+func (suite *ReportTestSuite) TestBuildMergesSources() {
+	// Прогулку 8:00–9:00 прислали часы, а телефон — её вторую половину и ещё полчаса.
+	entries := []journal.Entry{
+		{Time: day(12, 8), Kind: journal.KindSteps, Record: "6000,1h00m,source=watch"},
+		{Time: day(12, 8).Add(30 * time.Minute), Kind: journal.KindSteps, Record: "4000,1h00m,source=phone"},
+	}
+	p := profile.Profile{Weight: 75, Height: 1.75}
+
+	r := NewBuilder(config.Default(), nil).Build(entries, p, day(12, 0), day(13, 0))
+	d := r.Days[0]
+	assert.Equal(suite.T(), 8000, d.Steps)
+	assert.InDelta(suite.T(), 5.2, d.Distance, 1e-9)
+	assert.Equal(suite.T(), map[string]int{"watch": 6000, "phone": 2000}, d.Sources)
+	assert.Equal(suite.T(), 2000, d.Duplicates)
+	assert.Equal(suite.T(), 6000, d.Hourly[8])
+	assert.Equal(suite.T(), 2000, d.Hourly[9])
+	assert.Contains(suite.T(), r.Text(), "; повторных шагов не учтено 2000 (phone 2000, watch 6000)\n")
+
+	cfg := config.Default()
+	cfg.SourcePriority = []string{"phone"}
+	d = NewBuilder(cfg, nil).Build(entries, p, day(12, 0), day(13, 0)).Days[0]
+	assert.Equal(suite.T(), 7000, d.Steps)
+	assert.Equal(suite.T(), map[string]int{"watch": 3000, "phone": 4000}, d.Sources)
+}
+
+func (suite *ReportTestSuite) TestBuildDST() {
+	berlin, err := zone.Load("Europe/Berlin")
+	suite.Require().NoError(err)
+	at := func(d, h, min int) time.Time {
+		return time.Date(2026, 10, d, h, min, 0, 0, berlin)
+	}
+	// 25 октября 2026 года в Берлине час 2:00–3:00 повторяется: сутки длятся 25 часов.
+	repeated := at(25, 0, 0).Add(2*time.Hour + 30*time.Minute) // 2:30 по летнему времени.
+	entries := []journal.Entry{
+		{Time: repeated, Kind: journal.KindSteps, Record: "1000,30m"},
+		{Time: repeated.Add(time.Hour), Kind: journal.KindSteps, Record: "1000,30m"},
+		{Time: at(25, 23, 30), Kind: journal.KindSteps, Record: "500,15m"},
+	}
+	p := profile.Profile{Weight: 75, Height: 1.75}
+
+	r := NewBuilder(config.Default(), nil).Build(entries, p, at(24, 0, 0), at(27, 0, 0))
+	suite.Require().Len(r.Days, 3)
+	assert.Equal(suite.T(), at(26, 0, 0), r.Days[2].Date)
+	d := r.Days[1]
+	assert.Equal(suite.T(), 2500, d.Steps, "23:30 по зимнему времени — ещё 25 октября")
+	assert.Equal(suite.T(), 2000, d.Hourly[2], "оба повторённых часа попадают в одну ячейку")
+	assert.Equal(suite.T(), 500, d.Hourly[23])
+	assert.Zero(suite.T(), r.Days[0].Steps)
+	assert.Zero(suite.T(), r.Days[2].Steps)
+
+	// 29 марта 2026 года час 2:00–3:00 пропущен: сутки длятся 23 часа.
+	spring := time.Date(2026, 3, 29, 0, 0, 0, 0, berlin)
+	entries = []journal.Entry{{Time: spring.Add(22*time.Hour + 30*time.Minute), Kind: journal.KindSteps, Record: "1000,30m"}}
+	r = NewBuilder(config.Default(), nil).Build(entries, p, spring, zone.Date(2026, 3, 31, berlin))
+	suite.Require().Len(r.Days, 2)
+	assert.Equal(suite.T(), 1000, r.Days[0].Steps)
+	assert.Equal(suite.T(), 1000, r.Days[0].Hourly[23], "22:30 UTC+2 после перехода — 23:30 по часам")
+}
+
+func (suite *ReportTestSuite) TestBuildRecordZone() {
+	berlin, err := zone.Load("Europe/Berlin")
+	suite.Require().NoError(err)
+	newYork, err := zone.Load("America/New_York")
+	suite.Require().NoError(err)
+	from := zone.Date(2026, 10, 12, berlin)
+
+	// Вечерняя прогулка в Нью-Йорке: по берлинскому времени это уже 13 октября.
+	walk := time.Date(2026, 10, 12, 21, 0, 0, 0, newYork)
+	entries := []journal.Entry{
+		{Time: walk, Kind: journal.KindSteps, Record: "3000,30m,tz=America/New_York"},
+		{Time: walk.Add(time.Hour), Kind: journal.KindSteps, Record: "2000,30m", Line: 2},
+	}
+
+	r := NewBuilder(config.Default(), nil).Build(entries, profile.Profile{Weight: 75, Height: 1.75}, from, zone.Date(2026, 10, 14, berlin))
+	suite.Require().Len(r.Days, 2)
+	assert.Equal(suite.T(), 3000, r.Days[0].Steps, "запись с поясом относится к дню по местному времени")
+	assert.Equal(suite.T(), 3000, r.Days[0].Hourly[21])
+	assert.Equal(suite.T(), 2000, r.Days[1].Steps)
+	assert.Equal(suite.T(), 2000, r.Days[1].Hourly[4])
+}
+
+func (suite *ReportTestSuite) TestBuildMidnightOverlap() {
+	// Часы и телефон записали одну прогулку через полночь, но телефон начал её позже.
+	entries := []journal.Entry{
+		{Time: day(12, 23), Kind: journal.KindSteps, Record: "6000,2h00m,source=watch"},
+		{Time: day(13, 0), Kind: journal.KindSteps, Record: "3000,1h00m,source=phone", Line: 2},
+	}
+
+	r := NewBuilder(config.Default(), nil).Build(entries, profile.Profile{Weight: 75, Height: 1.75}, day(12, 0), day(14, 0))
+	assert.Equal(suite.T(), 6000, r.Days[0].Steps, "пакет относится к дню начала")
+	assert.Zero(suite.T(), r.Days[1].Steps, "пересечение через полночь не учитывается дважды")
+	assert.Equal(suite.T(), 3000, r.Days[1].Duplicates)
+	assert.Equal(suite.T(), 6000, r.Totals().Steps)
+	assert.Contains(suite.T(), r.Text(), "13.10.2026: шагов 0, 0.00 км, 0.00 ккал; повторных шагов не учтено 3000\n")
+}
+
+func (suite *ReportTestSuite) TestBuildTrainingTime() {
+	berlin, err := zone.Load("Europe/Berlin")
+	suite.Require().NoError(err)
+	from := zone.Date(2026, 10, 12, berlin)
+
+	// Тренировка введена со смещением Нью-Йорка, но по Берлину это уже 13 октября.
+	start := time.Date(2026, 10, 12, 21, 0, 0, 0, time.FixedZone("EDT", -4*60*60))
+	entries := []journal.Entry{{Time: start, Kind: journal.KindTraining, Record: "6000,Бег,1h00m"}}
+
+	r := NewBuilder(config.Default(), nil).Build(entries, profile.Profile{Weight: 75, Height: 1.75}, from, zone.Date(2026, 10, 14, berlin))
+	suite.Require().Len(r.Days[1].Trainings, 1)
+	tr := r.Days[1].Trainings[0]
+	assert.True(suite.T(), tr.Time.Equal(start))
+	assert.Equal(suite.T(), "13.10.2026 03:00", tr.Time.Format("02.01.2006 15:04"), "время выводится в поясе дня")
+}
+
+func (suite *ReportTestSuite) TestBuildRecovery() {
+	entries := []journal.Entry{
+		{Time: day(12, 7), Kind: journal.KindSleep, Record: "23:15,07:05,quality=4"},
+		{Time: day(12, 15), Kind: journal.KindSleep, Record: "14:00,14:30,quality=2"},
+		{Time: day(12, 8), Kind: journal.KindSteps, Record: "6000,1h00m"},
+		{Time: day(13, 0), Kind: journal.KindRest, Record: "после полумарафона"},
+		{Time: day(13, 7), Kind: journal.KindSleep, Record: "23:15,7h", Line: 5},
+	}
+
+	var logs bytes.Buffer
+	b := NewBuilder(config.Default(), slog.New(slog.NewTextHandler(&logs, nil)))
+	r := b.Build(entries, profile.Profile{Weight: 75, Height: 1.75}, day(12, 0), day(14, 0))
+
+	d := r.Days[0]
+	suite.Require().Len(d.Sleep, 2)
+	assert.Equal(suite.T(), day(11, 23).Add(15*time.Minute), d.Sleep[0].Bedtime, "ночь относится ко дню пробуждения")
+	assert.Equal(suite.T(), 8*time.Hour+20*time.Minute, d.SleepDuration())
+	assert.InDelta(suite.T(), 3, d.SleepQuality(), 1e-9)
+	assert.Nil(suite.T(), d.Rest)
+	suite.Require().NotNil(suite.T(), r.Days[1].Rest)
+	assert.Equal(suite.T(), "после полумарафона", r.Days[1].Rest.Note)
+	assert.Equal(suite.T(), 1, r.Skipped)
+	assert.Contains(suite.T(), logs.String(), "line=5")
+
+	assert.Equal(suite.T(), "Отчёт за 12.10.2026 — 13.10.2026\n"+
+		"12.10.2026: шагов 6000, 3.90 км, 177.19 ккал; сон 8.33 ч., качество 3.0 из 5\n"+
+		"13.10.2026: шагов 0, 0.00 км, 0.00 ккал; день отдыха: после полумарафона\n"+
+		"Итого: шагов 6000, 3.90 км, 177.19 ккал; тренировок 0: 0.00 км, 0.00 ккал\n"+
+		"Восстановление: сон в среднем 8.33 ч. в сутки по 1 дн.; дней отдыха 1\n"+
+		"Пропущено записей с ошибками: 1\n", r.Text())
+}
+
+func (suite *ReportTestSuite) TestSpread() {
+	var hourly [24]int
+	spread(&hourly, day(12, 7).Add(30*time.Minute), 2*time.Hour, 1000)
+	assert.Equal(suite.T(), 250, hourly[7])
+	assert.Equal(suite.T(), 500, hourly[8])
+	assert.Equal(suite.T(), 250, hourly[9])
+
+	hourly = [24]int{}
+	spread(&hourly, day(12, 23), 2*time.Hour, 1001)
+	assert.Equal(suite.T(), 1001, hourly[23], "шаги после полуночи относятся к последнему часу")
+}
+
+func (suite *ReportTestSuite) TestTrainingPace() {
+	entries := []journal.Entry{
+		{Time: day(12, 8), Kind: journal.KindTraining, Record: "3000,Бег,15m;1000,Ходьба,10m;3000,Бег,15m"},
+		{Time: day(12, 18), Kind: journal.KindTraining, Record: "3000,Ходьба,30m;3000,Ходьба,30m", Line: 2},
+	}
+	r := NewBuilder(config.Default(), nil).Build(entries, profile.Profile{Weight: 75, Height: 1.75}, day(12, 0), day(13, 0))
+	suite.Require().Len(r.Days[0].Trainings, 2)
+
+	p, ok := r.Days[0].Trainings[0].Pace()
+	suite.Require().True(ok, "у смешанной тренировки темп считается по бегу")
+	assert.Equal(suite.T(), "6:21", p.Clock())
+	_, ok = r.Days[0].Trainings[1].Pace()
+	assert.False(suite.T(), ok, "у тренировки только из ходьбы темпа нет")
+	assert.Contains(suite.T(), r.Text(), "; темп бега 6:21 мин/км\n")
+}
@@ -0,0 +1,36 @@
+package spentcalories
+
+import (
+	"log/slog"
+
+	"github.com/Yandex-Practicum/tracker/internal/config"
+)
+
+// Calculator рассчитывает показатели тренировок с коэффициентами из конфигурации.
+type Calculator struct {
+	cfg    config.Config
+	logger *slog.Logger
+}
+
+// NewCalculator возвращает калькулятор с коэффициентами cfg.
+func NewCalculator(cfg config.Config) Calculator {
+	return Calculator{cfg: cfg}
+}
+
+// WithLogger возвращает копию калькулятора, пишущую в журнал logger.
+// По умолчанию используется slog.Default().
+func (c Calculator) WithLogger(logger *slog.Logger) Calculator {
+	c.logger = logger
+	return c
+}
+
+// log возвращает журнал калькулятора.
+func (c Calculator) log() *slog.Logger {
+	if c.logger == nil {
+		return slog.Default()
+	}
+	return c.logger
+}
+
+// defaultCalculator используется функциями пакета и работает с коэффициентами по умолчанию.
+var defaultCalculator = NewCalculator(config.Default())
@@ -0,0 +1,25 @@
+package spentcalories
+
+import (
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *SpentCaloriesTestSuite) TestCalculatorConfig() {
+	cfg := config.Default()
+	cfg.StepLengthCoefficient = 0.5
+	cfg.WalkingCaloriesCoefficient = 0.25
+	c := NewCalculator(cfg)
+
+	assert.Equal(suite.T(), 1.0, c.distance(1000, 2))
+
+	got, err := c.WalkingSpentCalories(6000, 75, 2, time.Hour)
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 75*6*0.25, got, 1e-9)
+
+	info, err := c.TrainingInfo("6000,Бег,1h00m", 75, 2)
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), info, "Дистанция: 6.00 км.\n")
+}
@@ -0,0 +1,120 @@
+package spentcalories
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/parseerr"
+)
+
+// CyclingType — езда на велосипеде. Шаги не считаются: запись содержит
+// дистанцию ("25.5km") или среднюю скорость ("21km/h") вместо количества шагов.
+const CyclingType = "Велосипед"
+
+const (
+	// cyclingEfficiency — механический КПД велосипедиста на подъёме.
+	cyclingEfficiency = 0.25
+	// gravity — ускорение свободного падения, м/с².
+	gravity = 9.81
+	// jInKcal — количество джоулей в килокалории.
+	jInKcal = 4184
+)
+
+// Суффиксы первого поля записи велотренировки.
+var (
+	speedSuffixes    = []string{"km/h", "км/ч"}
+	distanceSuffixes = []string{"km", "км"}
+)
+
+// cyclingMET — метаболические эквиваленты езды на велосипеде по диапазонам
+// скорости из справочника физической активности (Compendium of Physical Activities).
+var cyclingMET = []struct {
+	maxSpeed float64 // верхняя граница диапазона, км/ч, не включается.
+	met      float64
+}{
+	{maxSpeed: 16, met: 4},
+	{maxSpeed: 19.2, met: 6.8},
+	{maxSpeed: 22.4, met: 8},
+	{maxSpeed: 25.6, met: 10},
+	{maxSpeed: 30.6, met: 12},
+}
+
+// maxCyclingMET — метаболический эквивалент для скорости выше всех диапазонов.
+const maxCyclingMET = 15.8
+
+// parseCycling разбирает дистанцию или среднюю скорость велотренировки
+// и возвращает дистанцию в километрах.
+func parseCycling(field string, duration time.Duration) (float64, error) {
+	for _, suffix := range speedSuffixes {
+		if value, ok := strings.CutSuffix(field, suffix); ok {
+			speed, err := parsePositive("speed", "средняя скорость", value)
+			if err != nil {
+				return 0, err
+			}
+			return speed * duration.Hours(), nil
+		}
+	}
+	for _, suffix := range distanceSuffixes {
+		if value, ok := strings.CutSuffix(field, suffix); ok {
+			return parsePositive("distance", "дистанция", value)
+		}
+	}
+	return 0, parseerr.New("distance", parseerr.KindFormat,
+		"для велотренировки ожидается дистанция вида \"25.5km\" или скорость вида \"21km/h\", получено: %q", field)
+}
+
+// parsePositive разбирает положительное число value для поля field с описанием name.
+func parsePositive(field, name, value string) (float64, error) {
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, parseerr.New(field, parseerr.KindSyntax, "некорректная %s: %w", name, err)
+	}
+	if v <= 0 {
+		return 0, parseerr.New(field, parseerr.KindRange, "%s должна быть больше нуля: %.2f", name, v)
+	}
+	return v, nil
+}
+
+// cyclingMETAt возвращает метаболический эквивалент езды со скоростью speed (км/ч).
+func cyclingMETAt(speed float64) float64 {
+	for _, band := range cyclingMET {
+		if speed < band.maxSpeed {
+			return band.met
+		}
+	}
+	return maxCyclingMET
+}
+
+// CyclingSpentCalories возвращает количество калорий, потраченных на дистанции
+// distance (км) на велосипеде: MET диапазона скорости, умноженный на вес и часы.
+func CyclingSpentCalories(distance, weight float64, duration time.Duration) (float64, error) {
+	return defaultCalculator.CyclingSpentCalories(distance, weight, duration)
+}
+
+// CyclingSpentCalories возвращает калории езды на велосипеде.
+func (c Calculator) CyclingSpentCalories(distance, weight float64, duration time.Duration) (float64, error) {
+	return c.CyclingSpentCaloriesOnTerrain(distance, weight, duration, Terrain{})
+}
+
+// CyclingSpentCaloriesOnTerrain возвращает калории езды на велосипеде с учётом рельефа:
+// к расходу на равнине добавляется работа против силы тяжести на подъёме.
+func CyclingSpentCaloriesOnTerrain(distance, weight float64, duration time.Duration, terrain Terrain) (float64, error) {
+	return defaultCalculator.CyclingSpentCaloriesOnTerrain(distance, weight, duration, terrain)
+}
+
+// CyclingSpentCaloriesOnTerrain возвращает калории езды по рельефу.
+func (c Calculator) CyclingSpentCaloriesOnTerrain(distance, weight float64, duration time.Duration, terrain Terrain) (float64, error) {
+	switch {
+	case distance <= 0:
+		return 0, parseerr.New("distance", parseerr.KindRange, "дистанция должна быть больше нуля: %.2f", distance)
+	case weight <= 0:
+		return 0, parseerr.New("weight", parseerr.KindRange, "вес должен быть больше нуля: %.2f", weight)
+	case duration <= 0:
+		return 0, parseerr.New("duration", parseerr.KindRange, "продолжительность должна быть больше нуля: %s", duration)
+	}
+
+	flat := cyclingMETAt(distance/duration.Hours()) * weight * duration.Hours()
+	climb := weight * gravity * terrain.climb(distance) / cyclingEfficiency / jInKcal
+	return flat + climb, nil
+}
@@ -0,0 +1,91 @@
+package spentcalories
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *SpentCaloriesTestSuite) TestParseCycling() {
+	tests := []struct {
+		name    string
+		input   string
+		want    Segment
+		wantErr bool
+	}{
+		{
+			name:  "дистанция",
+			input: "25.5km,Велосипед,1h00m",
+			want:  Segment{Type: CyclingType, RecordedDistance: 25.5, Duration: time.Hour},
+		},
+		{
+			name:  "скорость по-русски",
+			input: "20км/ч,Велосипед,1h30m,gain=200",
+			want:  Segment{Type: CyclingType, RecordedDistance: 30, Duration: 90 * time.Minute, Terrain: Terrain{Gain: 200}},
+		},
+		{
+			name:    "шаги вместо дистанции",
+			input:   "6000,Велосипед,1h00m",
+			wantErr: true,
+		},
+		{
+			name:    "нулевая дистанция",
+			input:   "0km,Велосипед,1h00m",
+			wantErr: true,
+		},
+		{
+			name:    "некорректная скорость",
+			input:   "быстроkm/h,Велосипед,1h00m",
+			wantErr: true,
+		},
+		{
+			name:    "нулевая продолжительность",
+			input:   "20km,Велосипед,0h",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			got, err := parseRecord(tt.input)
+			if tt.wantErr {
+				assert.Error(suite.T(), err)
+				return
+			}
+			assert.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tt.want, got)
+		})
+	}
+}
+
+func (suite *SpentCaloriesTestSuite) TestCyclingSpentCalories() {
+	// 20 км/ч — диапазон 19.2–22.4 км/ч, MET 8.
+	got, err := CyclingSpentCalories(20, 75, time.Hour)
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 600, got, 1e-9)
+
+	slow, _ := CyclingSpentCalories(10, 75, time.Hour)
+	fast, _ := CyclingSpentCalories(35, 75, time.Hour)
+	assert.InDelta(suite.T(), 300, slow, 1e-9)
+	assert.InDelta(suite.T(), 1185, fast, 1e-9)
+
+	hill, err := CyclingSpentCaloriesOnTerrain(20, 75, time.Hour, Terrain{Gain: 500})
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 600+75*9.81*500/0.25/4184, hill, 1e-9)
+
+	_, err = CyclingSpentCalories(0, 75, time.Hour)
+	assert.Error(suite.T(), err)
+	_, err = CyclingSpentCalories(20, 0, time.Hour)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *SpentCaloriesTestSuite) TestTrainingInfoCycling() {
+	got, err := TrainingInfo("30km,Велосипед,1h30m", 75, 1.75)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Тип тренировки: Велосипед\nДлительность: 1.50 ч.\nДистанция: 30.00 км.\nСкорость: 20.00 км/ч\nСожгли калорий: 900.00\n", got)
+
+	result, err := Summarize("1200,Бег,6m;4km,Велосипед,10m", 75, 1.75)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), IntervalType, result.Type())
+	assert.InDelta(suite.T(), 24, result.Segments[1].Speed, 1e-9)
+}
@@ -0,0 +1,230 @@
+package spentcalories
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/parseerr"
+)
+
+// riegelExponent — показатель степени в формуле Ригеля по умолчанию.
+const riegelExponent = 1.06
+
+// RaceDistance — стандартная соревновательная дистанция.
+type RaceDistance struct {
+	Name     string
+	Distance float64 // км.
+}
+
+// RaceDistances — дистанции, для которых строится прогноз.
+var RaceDistances = []RaceDistance{
+	{Name: "5 км", Distance: 5},
+	{Name: "10 км", Distance: 10},
+	{Name: "Полумарафон", Distance: 21.0975},
+	{Name: "Марафон", Distance: 42.195},
+}
+
+// RaceModel прогнозирует время на дистанции target (км)
+// по результату duration на дистанции dist (км).
+type RaceModel func(dist float64, duration time.Duration, target float64) time.Duration
+
+// Riegel возвращает модель Ригеля T2 = T1 · (D2 / D1)^exponent.
+func Riegel(exponent float64) RaceModel {
+	return func(dist float64, duration time.Duration, target float64) time.Duration {
+		return time.Duration(float64(duration) * math.Pow(target/dist, exponent))
+	}
+}
+
+// DefaultRaceModel — модель Ригеля с показателем 1.06.
+var DefaultRaceModel = Riegel(riegelExponent)
+
+// Split — время прохождения отрезка дистанции. Все отрезки, кроме последнего, равны километру.
+type Split struct {
+	Distance float64 // км.
+	Duration time.Duration
+}
+
+// Projection — прогноз времени на соревновательной дистанции.
+type Projection struct {
+	RaceDistance
+	Duration time.Duration
+}
+
+// Pace — темп, раскладка по километрам и прогнозы для тренировки.
+type Pace struct {
+	PerKm       time.Duration // время на километр.
+	Splits      []Split
+	Projections []Projection
+}
+
+// piece — участок с постоянной скоростью.
+type piece struct {
+	distance float64 // км.
+	duration time.Duration
+}
+
+// calculatePace рассчитывает темп по участкам с постоянной скоростью.
+// При model, равной nil, используется DefaultRaceModel.
+func calculatePace(pieces []piece, model RaceModel) (Pace, error) {
+	if model == nil {
+		model = DefaultRaceModel
+	}
+
+	var (
+		totalDist float64
+		totalTime time.Duration
+	)
+	for _, p := range pieces {
+		totalDist += p.distance
+		totalTime += p.duration
+	}
+	if totalDist <= 0 || totalTime <= 0 {
+		return Pace{}, errors.New("для расчёта темпа нужны положительные дистанция и продолжительность")
+	}
+
+	pace := Pace{PerKm: time.Duration(float64(totalTime) / totalDist)}
+
+	// Проходим участки, отмечая момент пересечения каждой километровой отметки.
+	var (
+		covered  float64       // пройдено к началу участка, км.
+		elapsed  time.Duration // затрачено к началу участка.
+		lastMark float64       // последняя пройденная отметка, км.
+		lastTime time.Duration // момент прохождения последней отметки.
+	)
+	for _, p := range pieces {
+		for mark := lastMark + 1; mark <= covered+p.distance; mark++ {
+			at := elapsed + time.Duration(float64(p.duration)*(mark-covered)/p.distance)
+			pace.Splits = append(pace.Splits, Split{Distance: 1, Duration: at - lastTime})
+			lastMark, lastTime = mark, at
+		}
+		covered += p.distance
+		elapsed += p.duration
+	}
+	if rest := totalDist - lastMark; rest > 1e-9 {
+		pace.Splits = append(pace.Splits, Split{Distance: rest, Duration: totalTime - lastTime})
+	}
+
+	for _, race := range RaceDistances {
+		pace.Projections = append(pace.Projections, Projection{
+			RaceDistance: race,
+			Duration:     model(totalDist, totalTime, race.Distance),
+		})
+	}
+
+	return pace, nil
+}
+
+// RunningPace возвращает темп, раскладку и прогнозы для беговой тренировки.
+func RunningPace(steps int, height float64, duration time.Duration, model RaceModel) (Pace, error) {
+	return defaultCalculator.RunningPace(steps, height, duration, model)
+}
+
+// RunningPace возвращает темп беговой тренировки с коэффициентами калькулятора.
+func (c Calculator) RunningPace(steps int, height float64, duration time.Duration, model RaceModel) (Pace, error) {
+	if steps <= 0 || height <= 0 || duration <= 0 {
+		return Pace{}, errors.New("для расчёта темпа нужны положительные шаги, рост и продолжительность")
+	}
+	return calculatePace([]piece{{distance: c.distance(steps, height), duration: duration}}, model)
+}
+
+// Pace возвращает темп, раскладку и прогнозы для тренировки из отрезков.
+func (r SessionResult) Pace(model RaceModel) (Pace, error) {
+	pieces := make([]piece, 0, len(r.Segments))
+	for _, seg := range r.Segments {
+		pieces = append(pieces, piece{distance: seg.Distance, duration: seg.Duration})
+	}
+	return calculatePace(pieces, model)
+}
+
+// RunningPace возвращает темп, раскладку и прогнозы по беговым отрезкам
+// тренировки, как если бы они шли подряд; остальные отрезки, например
+// ходьба между интервалами, не учитываются. Без беговых отрезков возвращает ошибку.
+func (r SessionResult) RunningPace(model RaceModel) (Pace, error) {
+	var running SessionResult
+	for _, seg := range r.Segments {
+		if seg.Type == RunningType {
+			running.Segments = append(running.Segments, seg)
+		}
+	}
+	if len(running.Segments) == 0 {
+		return Pace{}, parseerr.New("type", parseerr.KindUnknown, "темп рассчитывается только для бега, получено: %q", r.Type())
+	}
+	return running.Pace(model)
+}
+
+// PaceInfo возвращает темп, раскладку по километрам и прогнозы по беговым
+// отрезкам тренировки, см. SessionResult.RunningPace. Дополняет вывод TrainingInfo,
+// текст которого для бега остаётся прежним ради совместимости.
+func PaceInfo(data string, weight, height float64, model RaceModel) (string, error) {
+	return defaultCalculator.PaceInfo(data, weight, height, model)
+}
+
+// PaceInfo возвращает сводку по темпу с коэффициентами калькулятора.
+func (c Calculator) PaceInfo(data string, weight, height float64, model RaceModel) (string, error) {
+	var (
+		pace Pace
+		err  error
+	)
+	if strings.Contains(data, segmentSeparator) {
+		var session Session
+		session, err = ParseSession(data)
+		if err != nil {
+			return "", err
+		}
+		var result SessionResult
+		result, err = c.CalculateSession(session, weight, height)
+		if err != nil {
+			return "", err
+		}
+		pace, err = result.RunningPace(model)
+	} else {
+		seg, parseErr := parseRecord(data)
+		if parseErr != nil {
+			return "", parseErr
+		}
+		if seg.Type != RunningType {
+			return "", parseerr.New("type", parseerr.KindUnknown, "темп рассчитывается только для бега, получено: %q", seg.Type)
+		}
+		if seg.Treadmill.Speed > 0 {
+			pace, err = calculatePace([]piece{{distance: c.segmentDistance(seg, height), duration: seg.Duration}}, model)
+		} else {
+			pace, err = c.RunningPace(seg.Steps, height, seg.Duration, model)
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return pace.info(), nil
+}
+
+// Clock возвращает время на километр в виде "м:сс", например "6:21".
+func (p Pace) Clock() string {
+	return formatClock(p.PerKm, false)
+}
+
+// info возвращает текстовое представление темпа.
+func (p Pace) info() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Темп: %s мин/км\n", p.Clock())
+	for i, s := range p.Splits {
+		fmt.Fprintf(&b, "Сплит %d (%.2f км): %s\n", i+1, s.Distance, formatClock(s.Duration, false))
+	}
+	for _, pr := range p.Projections {
+		fmt.Fprintf(&b, "Прогноз на %s: %s\n", pr.Name, formatClock(pr.Duration, true))
+	}
+	return b.String()
+}
+
+// formatClock форматирует продолжительность как "м:сс" или, с часами, "ч:мм:сс".
+func formatClock(d time.Duration, withHours bool) string {
+	total := int(d.Round(time.Second).Seconds())
+	h, m, s := total/3600, total%3600/60, total%60
+	if withHours {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", h*60+m, s)
+}
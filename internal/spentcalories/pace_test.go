@@ -0,0 +1,93 @@
+package spentcalories
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *SpentCaloriesTestSuite) TestRiegel() {
+	model := Riegel(1.06)
+	got := model(5, 25*time.Minute, 10)
+	assert.InDelta(suite.T(), float64(52*time.Minute+7*time.Second), float64(got), float64(time.Second))
+
+	linear := Riegel(1)
+	assert.Equal(suite.T(), 50*time.Minute, linear(5, 25*time.Minute, 10))
+}
+
+func (suite *SpentCaloriesTestSuite) TestRunningPace() {
+	// 6000 шагов при росте 1.75 — 4.725 км.
+	got, err := RunningPace(6000, 1.75, 30*time.Minute, Riegel(1))
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), float64(30*time.Minute)/4.725, float64(got.PerKm), 1)
+
+	assert.Len(suite.T(), got.Splits, 5)
+	for _, s := range got.Splits[:4] {
+		assert.Equal(suite.T(), 1.0, s.Distance)
+		assert.InDelta(suite.T(), float64(got.PerKm), float64(s.Duration), 1)
+	}
+	assert.InDelta(suite.T(), 0.725, got.Splits[4].Distance, 1e-9)
+
+	assert.Len(suite.T(), got.Projections, len(RaceDistances))
+	assert.Equal(suite.T(), "5 км", got.Projections[0].Name)
+	assert.InDelta(suite.T(), float64(got.PerKm*5), float64(got.Projections[0].Duration), float64(time.Millisecond))
+
+	_, err = RunningPace(0, 1.75, time.Hour, nil)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *SpentCaloriesTestSuite) TestSessionPaceSplits() {
+	// 1 км за 4 минуты, затем 1 км за 8 минут: сплит пересекает границу отрезков.
+	result := SessionResult{Segments: []SegmentResult{
+		{Segment: Segment{Duration: 6 * time.Minute}, Distance: 1.5},
+		{Segment: Segment{Duration: 4 * time.Minute}, Distance: 0.5},
+	}}
+
+	got, err := result.Pace(nil)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []Split{
+		{Distance: 1, Duration: 4 * time.Minute},
+		{Distance: 1, Duration: 6 * time.Minute},
+	}, got.Splits)
+	assert.Equal(suite.T(), 5*time.Minute, got.PerKm)
+}
+
+func (suite *SpentCaloriesTestSuite) TestPaceInfo() {
+	got, err := PaceInfo("6000,Бег,30m", 75, 1.75, Riegel(1))
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), got, "Темп: 6:21 мин/км\n")
+	assert.Contains(suite.T(), got, "Сплит 5 (0.72 км): 4:36\n")
+	assert.Contains(suite.T(), got, "Прогноз на Марафон: 4:27:54\n")
+
+	_, err = PaceInfo("6000,Ходьба,30m", 75, 1.75, nil)
+	assert.Error(suite.T(), err)
+
+	// В интервальной тренировке темп считается только по беговым отрезкам.
+	got, err = PaceInfo("3000,Бег,15m;1000,Ходьба,10m;3000,Бег,15m", 75, 1.75, Riegel(1))
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), got, "Темп: 6:21 мин/км\n")
+
+	_, err = PaceInfo("3000,Ходьба,30m;3000,Ходьба,30m", 75, 1.75, nil)
+	assert.Error(suite.T(), err, "в тренировке только из ходьбы темпа нет")
+}
+
+func (suite *SpentCaloriesTestSuite) TestSessionRunningPace() {
+	result := SessionResult{Segments: []SegmentResult{
+		{Segment: Segment{Type: RunningType, Duration: 5 * time.Minute}, Distance: 1},
+		{Segment: Segment{Type: WalkingType, Duration: 10 * time.Minute}, Distance: 1},
+		{Segment: Segment{Type: RunningType, Duration: 5 * time.Minute}, Distance: 1},
+	}}
+	got, err := result.RunningPace(nil)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 5*time.Minute, got.PerKm)
+	assert.Len(suite.T(), got.Splits, 2)
+
+	_, err = SessionResult{Segments: result.Segments[1:2]}.RunningPace(nil)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *SpentCaloriesTestSuite) TestFormatClock() {
+	assert.Equal(suite.T(), "5:07", formatClock(5*time.Minute+7*time.Second, false))
+	assert.Equal(suite.T(), "75:00", formatClock(75*time.Minute, false))
+	assert.Equal(suite.T(), "1:15:00", formatClock(75*time.Minute, true))
+}
@@ -0,0 +1,58 @@
+package spentcalories
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/parseerr"
+)
+
+const (
+	// powerSeparator разделяет значения ряда мощности, например "power=250/262/245".
+	powerSeparator = "/"
+	// jInKj — количество джоулей в килоджоуле.
+	jInKj = 1000
+)
+
+// parsePower разбирает среднюю мощность в ваттах или ряд мощности через "/",
+// снятый через равные промежутки времени, и возвращает среднюю мощность.
+func parsePower(value string) (float64, error) {
+	var sum float64
+	samples := strings.Split(value, powerSeparator)
+	for _, sample := range samples {
+		p, err := strconv.ParseFloat(sample, 64)
+		if err != nil {
+			return 0, parseerr.New("power", parseerr.KindSyntax, "некорректная мощность: %w", err)
+		}
+		if p < 0 {
+			return 0, parseerr.New("power", parseerr.KindRange, "мощность не может быть отрицательной: %.0f", p)
+		}
+		sum += p
+	}
+	avg := sum / float64(len(samples))
+	if avg <= 0 {
+		return 0, parseerr.New("power", parseerr.KindRange, "средняя мощность должна быть больше нуля: %.0f", avg)
+	}
+	return avg, nil
+}
+
+// RunningSpentCaloriesFromPower возвращает количество калорий, потраченных при беге
+// со средней мощностью power (Вт): работа в килоджоулях, делённая на КПД бега
+// и переведённая в килокалории. Датчик мощности учитывает рельеф сам.
+func RunningSpentCaloriesFromPower(power float64, duration time.Duration) (float64, error) {
+	return defaultCalculator.RunningSpentCaloriesFromPower(power, duration)
+}
+
+// RunningSpentCaloriesFromPower возвращает калории бега по мощности с КПД из конфигурации.
+func (c Calculator) RunningSpentCaloriesFromPower(power float64, duration time.Duration) (float64, error) {
+	switch {
+	case power <= 0:
+		return 0, parseerr.New("power", parseerr.KindRange, "мощность должна быть больше нуля: %.0f", power)
+	case duration <= 0:
+		return 0, parseerr.New("duration", parseerr.KindRange, "продолжительность должна быть больше нуля: %s", duration)
+	}
+
+	work := power * duration.Seconds() / jInKj // кДж.
+	return work / c.cfg.RunningPowerEfficiency * jInKj / jInKcal, nil
+}
@@ -0,0 +1,49 @@
+package spentcalories
+
+import (
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *SpentCaloriesTestSuite) TestParsePower() {
+	got, err := parsePower("250")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 250.0, got)
+
+	got, err = parsePower("240/260/0/300")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 200.0, got)
+
+	for _, value := range []string{"", "0", "-10", "250/x", "0/0"} {
+		_, err := parsePower(value)
+		assert.Error(suite.T(), err, value)
+	}
+}
+
+func (suite *SpentCaloriesTestSuite) TestRunningSpentCaloriesFromPower() {
+	// 250 Вт за час — 900 кДж работы, при КПД 0.24 — 3750 кДж затрат.
+	got, err := RunningSpentCaloriesFromPower(250, time.Hour)
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 3750/4.184, got, 1e-9)
+
+	cfg := config.Default()
+	cfg.RunningPowerEfficiency = 0.25
+	got, err = NewCalculator(cfg).RunningSpentCaloriesFromPower(250, time.Hour)
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 3600/4.184, got, 1e-9)
+
+	_, err = RunningSpentCaloriesFromPower(0, time.Hour)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *SpentCaloriesTestSuite) TestTrainingInfoPower() {
+	got, err := TrainingInfo("6000,Бег,1h00m,power=240/260", 75, 1.75)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Тип тренировки: Бег\nДлительность: 1.00 ч.\nДистанция: 4.72 км.\nСкорость: 4.72 км/ч\n"+
+		"Средняя мощность: 250 Вт\nСожгли калорий: 896.27\n", got)
+
+	_, err = TrainingInfo("6000,Ходьба,1h00m,power=250", 75, 1.75)
+	assert.Error(suite.T(), err, "мощность учитывается только для бега")
+}
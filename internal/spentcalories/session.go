@@ -0,0 +1,175 @@
+package spentcalories
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/meta"
+)
+
+const (
+	// segmentSeparator разделяет отрезки интервальной тренировки.
+	segmentSeparator = ";"
+	// IntervalType — тип тренировки из отрезков разных типов.
+	IntervalType = "Интервальная"
+)
+
+// Segment — отрезок тренировки со своим типом, шагами, продолжительностью и рельефом.
+type Segment struct {
+	Type     string
+	Steps    int
+	Duration time.Duration
+	Terrain  Terrain
+	// RecordedDistance — дистанция из записи, км, для занятий без шагов,
+	// например велотренировки. Для остальных отрезков равна 0.
+	RecordedDistance float64
+	// Swim — параметры заплыва для плавания.
+	Swim Swim
+	// HeartRate — средний пульс, уд/мин; 0, если не измерялся.
+	HeartRate float64
+	// Power — средняя мощность бега, Вт; 0, если не измерялась.
+	Power float64
+	// Treadmill — показания беговой дорожки для ходьбы и бега на ней.
+	Treadmill Treadmill
+	// Meta — происхождение отрезка: источник, устройство, приложение.
+	Meta meta.Meta
+}
+
+// SegmentResult — рассчитанные показатели отрезка.
+type SegmentResult struct {
+	Segment
+	Distance float64 // км.
+	Speed    float64 // км/ч.
+	Calories float64 // ккал.
+}
+
+// Session — тренировка из упорядоченных отрезков, например интервальная.
+type Session struct {
+	Segments []Segment
+}
+
+// SessionResult — показатели каждого отрезка и тренировки в целом.
+type SessionResult struct {
+	Segments []SegmentResult
+	Duration time.Duration
+	Distance float64 // км.
+	Speed    float64 // км/ч.
+	Calories float64 // ккал.
+}
+
+// ParseSession разбирает интервальную тренировку вида
+// "1200,Бег,5m;400,Ходьба,2m;1200,Бег,5m". Каждый отрезок записывается
+// так же, как одиночная тренировка, включая параметры рельефа и пульс.
+func ParseSession(data string) (Session, error) {
+	records := strings.Split(data, segmentSeparator)
+	segments := make([]Segment, 0, len(records))
+
+	for i, record := range records {
+		seg, err := parseRecord(record)
+		if err != nil {
+			return Session{}, fmt.Errorf("отрезок %d: %w", i+1, err)
+		}
+		segments = append(segments, seg)
+	}
+
+	return Session{Segments: segments}, nil
+}
+
+// Calculate рассчитывает дистанцию, скорость и калории по отрезкам и в сумме.
+func (s Session) Calculate(weight, height float64) (SessionResult, error) {
+	return defaultCalculator.CalculateSession(s, weight, height)
+}
+
+// CalculateSession рассчитывает показатели тренировки s с коэффициентами калькулятора.
+func (c Calculator) CalculateSession(s Session, weight, height float64) (SessionResult, error) {
+	if len(s.Segments) == 0 {
+		return SessionResult{}, fmt.Errorf("тренировка не содержит отрезков")
+	}
+
+	result := SessionResult{Segments: make([]SegmentResult, 0, len(s.Segments))}
+	for i, seg := range s.Segments {
+		calories, err := c.spentCalories(seg, weight, height)
+		if err != nil {
+			return SessionResult{}, fmt.Errorf("отрезок %d: %w", i+1, err)
+		}
+
+		dist := c.segmentDistance(seg, height)
+		segResult := SegmentResult{
+			Segment:  seg,
+			Distance: dist,
+			Speed:    dist / seg.Duration.Hours(),
+			Calories: calories,
+		}
+		result.Segments = append(result.Segments, segResult)
+		result.Duration += seg.Duration
+		result.Distance += segResult.Distance
+		result.Calories += segResult.Calories
+	}
+	result.Speed = result.Distance / result.Duration.Hours()
+
+	return result, nil
+}
+
+// SessionInfo возвращает сводку по интервальной тренировке:
+// показатели каждого отрезка и итог в формате TrainingInfo.
+func SessionInfo(data string, weight, height float64) (string, error) {
+	return defaultCalculator.SessionInfo(data, weight, height)
+}
+
+// SessionInfo возвращает сводку по интервальной тренировке с коэффициентами калькулятора.
+func (c Calculator) SessionInfo(data string, weight, height float64) (string, error) {
+	session, err := ParseSession(data)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := c.CalculateSession(session, weight, height)
+	if err != nil {
+		return "", err
+	}
+
+	c.log().Debug("интервальная тренировка рассчитана",
+		slog.String("record", data), slog.Int("segments", len(result.Segments)), slog.Float64("calories", result.Calories))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Интервальная тренировка, отрезков: %d\n", len(result.Segments))
+	for i, seg := range result.Segments {
+		fmt.Fprintf(&b, "%d. %s: %.2f ч., %.2f км, %.2f км/ч, %.2f ккал\n",
+			i+1, seg.Type, seg.Duration.Hours(), seg.Distance, seg.Speed, seg.Calories)
+	}
+	fmt.Fprintf(&b, "Длительность: %.2f ч.\nДистанция: %.2f км.\nСкорость: %.2f км/ч\nСожгли калорий: %.2f\n",
+		result.Duration.Hours(), result.Distance, result.Speed, result.Calories)
+
+	return b.String(), nil
+}
+
+// Summarize разбирает одиночную или интервальную тренировку и рассчитывает её показатели.
+// Одиночная тренировка считается сессией из одного отрезка.
+func Summarize(data string, weight, height float64) (SessionResult, error) {
+	return defaultCalculator.Summarize(data, weight, height)
+}
+
+// Summarize рассчитывает показатели тренировки с коэффициентами калькулятора.
+func (c Calculator) Summarize(data string, weight, height float64) (SessionResult, error) {
+	session, err := ParseSession(data)
+	if err != nil {
+		return SessionResult{}, err
+	}
+	return c.CalculateSession(session, weight, height)
+}
+
+// Type возвращает тип тренировки: общий тип всех отрезков или "Интервальная" для смешанных.
+func (r SessionResult) Type() string {
+	if len(r.Segments) == 0 {
+		return ""
+	}
+	t := r.Segments[0].Type
+	for _, seg := range r.Segments[1:] {
+		if seg.Type != t {
+			return IntervalType
+		}
+	}
+	return t
+}
@@ -0,0 +1,84 @@
+package spentcalories
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *SpentCaloriesTestSuite) TestParseSession() {
+	got, err := ParseSession("1200,Бег,5m;400,Ходьба,2m,grade=3")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), Session{Segments: []Segment{
+		{Type: "Бег", Steps: 1200, Duration: 5 * time.Minute},
+		{Type: "Ходьба", Steps: 400, Duration: 2 * time.Minute, Terrain: Terrain{Grade: 3}},
+	}}, got)
+
+	_, err = ParseSession("1200,Бег,5m;0,Ходьба,2m")
+	assert.ErrorContains(suite.T(), err, "отрезок 2")
+
+	_, err = ParseSession("1200,Бег,5m;")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *SpentCaloriesTestSuite) TestSessionCalculate() {
+	session := Session{Segments: []Segment{
+		{Type: "Бег", Steps: 3000, Duration: 30 * time.Minute},
+		{Type: "Ходьба", Steps: 3000, Duration: 30 * time.Minute},
+	}}
+
+	got, err := session.Calculate(75, 1.75)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), got.Segments, 2)
+	assert.InDelta(suite.T(), 177.1875, got.Segments[0].Calories, 0.001)
+	assert.InDelta(suite.T(), 88.59375, got.Segments[1].Calories, 0.001)
+	assert.Equal(suite.T(), time.Hour, got.Duration)
+	assert.InDelta(suite.T(), 4.725, got.Distance, 0.001)
+	assert.InDelta(suite.T(), 4.725, got.Speed, 0.001)
+	assert.InDelta(suite.T(), 265.78125, got.Calories, 0.001)
+
+	_, err = Session{}.Calculate(75, 1.75)
+	assert.Error(suite.T(), err)
+
+	_, err = Session{Segments: []Segment{{Type: "Плавание", Steps: 100, Duration: time.Minute}}}.Calculate(75, 1.75)
+	assert.ErrorContains(suite.T(), err, "неизвестный тип тренировки")
+}
+
+func (suite *SpentCaloriesTestSuite) TestTrainingInfoSession() {
+	got, err := TrainingInfo("3000,Бег,30m;3000,Ходьба,30m", 75, 1.75)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Интервальная тренировка, отрезков: 2\n"+
+		"1. Бег: 0.50 ч., 2.36 км, 4.72 км/ч, 177.19 ккал\n"+
+		"2. Ходьба: 0.50 ч., 2.36 км, 4.72 км/ч, 88.59 ккал\n"+
+		"Длительность: 1.00 ч.\nДистанция: 4.72 км.\nСкорость: 4.72 км/ч\nСожгли калорий: 265.78\n", got)
+}
+
+func (suite *SpentCaloriesTestSuite) TestSummarize() {
+	got, err := Summarize("6000,Бег,1h00m", 75, 1.75)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), got.Segments, 1)
+	assert.Equal(suite.T(), "Бег", got.Type())
+	assert.InDelta(suite.T(), 354.375, got.Calories, 0.001)
+
+	got, err = Summarize("3000,Бег,30m;3000,Ходьба,30m", 75, 1.75)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Интервальная", got.Type())
+
+	_, err = Summarize("6000,Плавание,1h00m", 75, 1.75)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *SpentCaloriesTestSuite) TestParseRecordHeartRate() {
+	got, err := parseRecord("6000,Бег,1h00m,hr=152,gain=40")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), Segment{Type: RunningType, Steps: 6000, Duration: time.Hour, Terrain: Terrain{Gain: 40}, HeartRate: 152}, got)
+
+	for _, data := range []string{"6000,Бег,1h00m,hr=0", "6000,Бег,1h00m,hr=x", "6000,Бег,1h00m,hr=150,hr=160"} {
+		_, err := parseRecord(data)
+		assert.Error(suite.T(), err, data)
+	}
+
+	info, err := TrainingInfo("6000,Бег,1h00m,hr=152", 75, 1.75)
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), info, "Средний пульс: 152 уд/мин\nСожгли калорий:")
+}
@@ -1,38 +1,340 @@
 package spentcalories
 
 import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/meta"
+	"github.com/Yandex-Practicum/tracker/internal/parseerr"
 )
 
 // Основные константы, необходимые для расчетов.
+// Коэффициенты длины шага и калорий при ходьбе задаются в config.Config.
 const (
-	lenStep                    = 0.65 // средняя длина шага.
-	mInKm                      = 1000 // количество метров в километре.
-	minInH                     = 60   // количество минут в часе.
-	stepLengthCoefficient      = 0.45 // коэффициент для расчета длины шага на основе роста.
-	walkingCaloriesCoefficient = 0.5  // коэффициент для расчета калорий при ходьбе
+	mInKm  = 1000 // количество метров в километре.
+	minInH = 60   // количество минут в часе.
 )
 
+// Поддерживаемые типы тренировок.
+const (
+	WalkingType = "Ходьба"
+	RunningType = "Бег"
+)
+
+// parseTraining разбирает строку тренировки вида "3456,Ходьба,3h00m"
+// на количество шагов, тип тренировки и продолжительность.
 func parseTraining(data string) (int, string, time.Duration, error) {
-	// TODO: реализовать функцию
+	parts := strings.Split(data, ",")
+	if len(parts) != 3 {
+		return 0, "", 0, parseerr.New("", parseerr.KindFormat, "неверный формат данных: ожидается \"шаги,тип,продолжительность\"")
+	}
+
+	steps, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", 0, parseerr.New("steps", parseerr.KindSyntax, "некорректное количество шагов: %w", err)
+	}
+	if steps <= 0 {
+		return 0, "", 0, parseerr.New("steps", parseerr.KindRange, "количество шагов должно быть больше нуля: %d", steps)
+	}
+
+	duration, err := parseDuration(parts[2])
+	if err != nil {
+		return 0, "", 0, err
+	}
+
+	return steps, parts[1], duration, nil
+}
+
+// parseDuration разбирает положительную продолжительность тренировки вида "1h30m".
+func parseDuration(s string) (time.Duration, error) {
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, parseerr.New("duration", parseerr.KindSyntax, "некорректная продолжительность: %w", err)
+	}
+	if duration <= 0 {
+		return 0, parseerr.New("duration", parseerr.KindRange, "продолжительность должна быть больше нуля: %s", duration)
+	}
+	return duration, nil
+}
+
+// parseRecord разбирает запись тренировки: три обязательных поля parseTraining
+// и необязательные параметры вида "ключ=значение" — рельеф, средний пульс hr,
+// мощность бега power, показания беговой дорожки belt и incline,
+// а также метаданные о происхождении записи из пакета meta.
+// Запись велотренировки вместо шагов содержит дистанцию или скорость,
+// у заплыва свой формат, описанный в SwimmingType.
+func parseRecord(data string) (Segment, error) {
+	parts := strings.Split(data, ",")
+	if len(parts) < 3 {
+		return Segment{}, parseerr.New("", parseerr.KindFormat, "неверный формат данных: ожидается \"шаги,тип,продолжительность\"")
+	}
+
+	var (
+		seg     Segment
+		options = parts[3:]
+	)
+	switch {
+	case parts[1] == SwimmingType && strings.Contains(parts[0], lapsSeparator):
+		var err error
+		seg, options, err = parseSwim(parts)
+		if err != nil {
+			return Segment{}, err
+		}
+	case parts[1] == CyclingType:
+		duration, err := parseDuration(parts[2])
+		if err != nil {
+			return Segment{}, err
+		}
+		dist, err := parseCycling(parts[0], duration)
+		if err != nil {
+			return Segment{}, err
+		}
+		seg = Segment{Type: CyclingType, RecordedDistance: dist, Duration: duration}
+	default:
+		steps, trainingType, duration, err := parseTraining(strings.Join(parts[:3], ","))
+		if err != nil {
+			return Segment{}, err
+		}
+		seg = Segment{Type: trainingType, Steps: steps, Duration: duration}
+	}
+
+	m, options, err := meta.Split(options)
+	if err != nil {
+		return Segment{}, err
+	}
+	seg.Meta = m
+
+	var terrainFields []string
+	// seen — уже указанные параметры дорожки: нулевой наклон неотличим от неуказанного.
+	seen := make(map[string]bool, len(options))
+	for _, field := range options {
+		key, value, _ := strings.Cut(field, "=")
+		switch key {
+		case "hr":
+			if seg.HeartRate != 0 {
+				return Segment{}, parseerr.New(key, parseerr.KindConflict, "параметр тренировки %q указан повторно", key)
+			}
+			hr, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return Segment{}, parseerr.New(key, parseerr.KindSyntax, "некорректный пульс: %w", err)
+			}
+			if hr <= 0 {
+				return Segment{}, parseerr.New(key, parseerr.KindRange, "пульс должен быть больше нуля: %.0f", hr)
+			}
+			seg.HeartRate = hr
+		case "power":
+			if seg.Power != 0 {
+				return Segment{}, parseerr.New(key, parseerr.KindConflict, "параметр тренировки %q указан повторно", key)
+			}
+			if seg.Type != RunningType {
+				return Segment{}, parseerr.New(key, parseerr.KindConflict, "мощность учитывается только для бега, получено: %q", seg.Type)
+			}
+			power, err := parsePower(value)
+			if err != nil {
+				return Segment{}, err
+			}
+			seg.Power = power
+		case "belt", "incline":
+			if seg.Type != WalkingType && seg.Type != RunningType {
+				return Segment{}, parseerr.New(key, parseerr.KindConflict, "беговая дорожка бывает только для ходьбы и бега, получено: %q", seg.Type)
+			}
+			if seen[key] {
+				return Segment{}, parseerr.New(key, parseerr.KindConflict, "параметр тренировки %q указан повторно", key)
+			}
+			seen[key] = true
+			if err := parseTreadmill(&seg.Treadmill, key, value); err != nil {
+				return Segment{}, err
+			}
+		default:
+			terrainFields = append(terrainFields, field)
+		}
+	}
+
+	terrain, err := parseTerrain(terrainFields)
+	if err != nil {
+		return Segment{}, err
+	}
+	if seg.Type == SwimmingType && terrain != (Terrain{}) {
+		return Segment{}, parseerr.New("", parseerr.KindConflict, "у заплыва не бывает рельефа")
+	}
+	if seg.Treadmill != (Treadmill{}) {
+		if seg.Treadmill.Speed == 0 {
+			return Segment{}, parseerr.New("belt", parseerr.KindFormat, "для беговой дорожки укажите скорость полотна belt")
+		}
+		if terrain != (Terrain{}) {
+			return Segment{}, parseerr.New("incline", parseerr.KindConflict, "на беговой дорожке рельеф задаётся наклоном incline")
+		}
+	}
+	seg.Terrain = terrain
+
+	return seg, nil
 }
 
+// distance возвращает дистанцию в километрах, рассчитанную по росту и количеству шагов.
 func distance(steps int, height float64) float64 {
-	// TODO: реализовать функцию
+	return defaultCalculator.distance(steps, height)
+}
+
+func (c Calculator) distance(steps int, height float64) float64 {
+	stepLength := height * c.cfg.StepLengthCoefficient
+	return float64(steps) * stepLength / mInKm
 }
 
+// meanSpeed возвращает среднюю скорость в км/ч.
+// Для неположительной продолжительности возвращает 0.
 func meanSpeed(steps int, height float64, duration time.Duration) float64 {
-	// TODO: реализовать функцию
+	return defaultCalculator.meanSpeed(steps, height, duration)
+}
+
+func (c Calculator) meanSpeed(steps int, height float64, duration time.Duration) float64 {
+	if duration <= 0 {
+		return 0
+	}
+	return c.distance(steps, height) / duration.Hours()
 }
 
+// TrainingInfo возвращает сводку по тренировке вида "3456,Ходьба,3h00m",
+// велотренировке вида "25km,Велосипед,1h10m" или заплыву вида "40x25m,Плавание,кроль,0h45m".
+// После обязательных полей запись может содержать параметры рельефа:
+// gain и loss — набор и сброс высоты в метрах, grade — средний уклон в процентах,
+// а также hr — средний пульс в ударах в минуту и, для бега, power — средняя
+// мощность в ваттах или ряд мощности через "/". С мощностью калории бега
+// считаются по работе, а не по скорости и весу.
+// Ходьба и бег на дорожке записываются с параметрами belt — скорость полотна в км/ч
+// и incline — наклон в процентах, например "9800,Бег,0h50m,belt=11.5,incline=1".
+// Дистанция и калории тогда считаются по скорости полотна, а скорость по шагам
+// выводится только для сверки.
+// Интервальная тренировка записывается отрезками через ";" и описана в SessionInfo.
 func TrainingInfo(data string, weight, height float64) (string, error) {
-	// TODO: реализовать функцию
+	return defaultCalculator.TrainingInfo(data, weight, height)
 }
 
+// TrainingInfo возвращает сводку по тренировке с коэффициентами калькулятора.
+func (c Calculator) TrainingInfo(data string, weight, height float64) (string, error) {
+	if strings.Contains(data, segmentSeparator) {
+		return c.SessionInfo(data, weight, height)
+	}
+
+	seg, err := parseRecord(data)
+	if err != nil {
+		return "", err
+	}
+
+	calories, err := c.spentCalories(seg, weight, height)
+	if err != nil {
+		return "", err
+	}
+	dist := c.segmentDistance(seg, height)
+
+	c.log().Debug("тренировка рассчитана",
+		slog.String("record", data), slog.String("type", seg.Type), slog.Float64("calories", calories))
+
+	info := fmt.Sprintf("Тип тренировки: %s\nДлительность: %.2f ч.\nДистанция: %.2f км.\nСкорость: %.2f км/ч\n",
+		seg.Type, seg.Duration.Hours(), dist, dist/seg.Duration.Hours())
+	info += seg.Terrain.info()
+	if seg.Treadmill.Speed > 0 {
+		info += seg.Treadmill.info(c.meanSpeed(seg.Steps, height, seg.Duration))
+	}
+	if seg.Type == SwimmingType {
+		info += seg.Swim.info(seg.Duration)
+	}
+	if seg.HeartRate > 0 {
+		info += fmt.Sprintf("Средний пульс: %.0f уд/мин\n", seg.HeartRate)
+	}
+	if seg.Power > 0 {
+		info += fmt.Sprintf("Средняя мощность: %.0f Вт\n", seg.Power)
+	}
+	info += fmt.Sprintf("Сожгли калорий: %.2f\n", calories)
+	info += seg.Meta.Text()
+
+	return info, nil
+}
+
+// spentCalories возвращает количество калорий для отрезка по модели его типа.
+func (c Calculator) spentCalories(seg Segment, weight, height float64) (float64, error) {
+	switch seg.Type {
+	case WalkingType:
+		if seg.Treadmill.Speed > 0 {
+			return c.WalkingSpentCaloriesOnTreadmill(seg.Treadmill, weight, seg.Duration)
+		}
+		return c.WalkingSpentCaloriesOnTerrain(seg.Steps, weight, height, seg.Duration, seg.Terrain)
+	case RunningType:
+		if seg.Power > 0 {
+			return c.RunningSpentCaloriesFromPower(seg.Power, seg.Duration)
+		}
+		if seg.Treadmill.Speed > 0 {
+			return c.RunningSpentCaloriesOnTreadmill(seg.Treadmill, weight, seg.Duration)
+		}
+		return c.RunningSpentCaloriesOnTerrain(seg.Steps, weight, height, seg.Duration, seg.Terrain)
+	case CyclingType:
+		return c.CyclingSpentCaloriesOnTerrain(seg.RecordedDistance, weight, seg.Duration, seg.Terrain)
+	case SwimmingType:
+		if seg.Swim.Laps > 0 {
+			return c.SwimmingSpentCalories(seg.Swim.Stroke, weight, seg.Duration)
+		}
+		return 0, parseerr.New("type", parseerr.KindUnknown,
+			"неизвестный тип тренировки для записи с шагами: %q; заплыв записывается как \"40x25m,Плавание,кроль,0h45m\"", seg.Type)
+	default:
+		return 0, parseerr.New("type", parseerr.KindUnknown, "неизвестный тип тренировки: %q", seg.Type)
+	}
+}
+
+// segmentDistance возвращает дистанцию отрезка в километрах: записанную
+// для занятий без шагов, пройденную по полотну дорожки или рассчитанную по шагам и росту.
+func (c Calculator) segmentDistance(seg Segment, height float64) float64 {
+	if seg.Treadmill.Speed > 0 {
+		return seg.Treadmill.Speed * seg.Duration.Hours()
+	}
+	if seg.Steps == 0 {
+		return seg.RecordedDistance
+	}
+	return c.distance(seg.Steps, height)
+}
+
+// validate проверяет общие для расчёта калорий параметры.
+func validate(steps int, weight, height float64, duration time.Duration) error {
+	switch {
+	case steps <= 0:
+		return parseerr.New("steps", parseerr.KindRange, "количество шагов должно быть больше нуля: %d", steps)
+	case weight <= 0:
+		return parseerr.New("weight", parseerr.KindRange, "вес должен быть больше нуля: %.2f", weight)
+	case height <= 0:
+		return parseerr.New("height", parseerr.KindRange, "рост должен быть больше нуля: %.2f", height)
+	case duration <= 0:
+		return parseerr.New("duration", parseerr.KindRange, "продолжительность должна быть больше нуля: %s", duration)
+	}
+	return nil
+}
+
+// RunningSpentCalories возвращает количество калорий, потраченных при беге.
 func RunningSpentCalories(steps int, weight, height float64, duration time.Duration) (float64, error) {
-	// TODO: реализовать функцию
+	return defaultCalculator.RunningSpentCalories(steps, weight, height, duration)
 }
 
+// RunningSpentCalories возвращает калории при беге с коэффициентами калькулятора.
+func (c Calculator) RunningSpentCalories(steps int, weight, height float64, duration time.Duration) (float64, error) {
+	if err := validate(steps, weight, height, duration); err != nil {
+		return 0, err
+	}
+
+	speed := c.meanSpeed(steps, height, duration)
+	return weight * speed * duration.Minutes() / minInH, nil
+}
+
+// WalkingSpentCalories возвращает количество калорий, потраченных при ходьбе.
 func WalkingSpentCalories(steps int, weight, height float64, duration time.Duration) (float64, error) {
-	// TODO: реализовать функцию
+	return defaultCalculator.WalkingSpentCalories(steps, weight, height, duration)
+}
+
+// WalkingSpentCalories возвращает калории при ходьбе с коэффициентами калькулятора.
+func (c Calculator) WalkingSpentCalories(steps int, weight, height float64, duration time.Duration) (float64, error) {
+	if err := validate(steps, weight, height, duration); err != nil {
+		return 0, err
+	}
+
+	speed := c.meanSpeed(steps, height, duration)
+	return weight * speed * duration.Minutes() / minInH * c.cfg.WalkingCaloriesCoefficient, nil
 }
@@ -700,3 +700,14 @@ func (suite *SpentCaloriesTestSuite) TestTrainingInfo() {
 		})
 	}
 }
+
+func (suite *SpentCaloriesTestSuite) TestTrainingInfoMeta() {
+	got, err := TrainingInfo("6000,Бег,1h00m,gain=100,source=watch,app=Connect", 75, 1.75)
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), got, "Сожгли калорий: 381.99\nДанные: источник watch, приложение Connect\n")
+
+	seg, err := parseRecord("6000,Бег,1h00m,gain=100,source=watch")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "watch", seg.Meta.Source)
+	assert.Equal(suite.T(), 100.0, seg.Terrain.Gain)
+}
@@ -0,0 +1,113 @@
+package spentcalories
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/parseerr"
+)
+
+// SwimmingType — плавание в бассейне. Запись заплыва имеет свой формат:
+// "круги x длина бассейна,Плавание,стиль,продолжительность", например
+// "40x25m,Плавание,кроль,0h45m".
+const SwimmingType = "Плавание"
+
+const (
+	// swimPaceDistance — отрезок, на который рассчитывается темп плавания, м.
+	swimPaceDistance = 100
+	// lapsSeparator отделяет число кругов от длины бассейна.
+	lapsSeparator = "x"
+)
+
+// strokeMET — метаболические эквиваленты стилей плавания для тренировочного
+// темпа из справочника физической активности (Compendium of Physical Activities).
+var strokeMET = map[string]float64{
+	"кроль":      8.3,
+	"брасс":      10.3,
+	"спина":      9.5,
+	"баттерфляй": 13.8,
+}
+
+// Swim — параметры заплыва. Нулевое значение у отрезков других типов.
+type Swim struct {
+	Laps       int
+	PoolLength float64 // м.
+	Stroke     string
+}
+
+// distance возвращает дистанцию заплыва в километрах.
+func (s Swim) distance() float64 {
+	return float64(s.Laps) * s.PoolLength / mInKm
+}
+
+// parseSwim разбирает запись заплыва, разбитую на поля, и возвращает отрезок
+// и необязательные параметры после продолжительности.
+func parseSwim(parts []string) (Segment, []string, error) {
+	if len(parts) < 4 {
+		return Segment{}, nil, parseerr.New("", parseerr.KindFormat, "неверный формат заплыва: ожидается \"кругиxдлинаm,Плавание,стиль,продолжительность\"")
+	}
+
+	lapsStr, poolStr, ok := strings.Cut(parts[0], lapsSeparator)
+	poolStr, hasUnit := strings.CutSuffix(poolStr, "m")
+	if !ok || !hasUnit {
+		return Segment{}, nil, parseerr.New("pool", parseerr.KindFormat, "ожидается число кругов и длина бассейна вида \"40x25m\", получено: %q", parts[0])
+	}
+	laps, err := strconv.Atoi(lapsStr)
+	if err != nil {
+		return Segment{}, nil, parseerr.New("laps", parseerr.KindSyntax, "некорректное число кругов: %w", err)
+	}
+	if laps <= 0 {
+		return Segment{}, nil, parseerr.New("laps", parseerr.KindRange, "число кругов должно быть больше нуля: %d", laps)
+	}
+	pool, err := parsePositive("pool", "длина бассейна", poolStr)
+	if err != nil {
+		return Segment{}, nil, err
+	}
+
+	stroke := parts[2]
+	if _, ok := strokeMET[stroke]; !ok {
+		return Segment{}, nil, parseerr.New("stroke", parseerr.KindUnknown, "неизвестный стиль плавания: %q", stroke)
+	}
+
+	duration, err := parseDuration(parts[3])
+	if err != nil {
+		return Segment{}, nil, err
+	}
+
+	swim := Swim{Laps: laps, PoolLength: pool, Stroke: stroke}
+	return Segment{
+		Type:             SwimmingType,
+		Duration:         duration,
+		RecordedDistance: swim.distance(),
+		Swim:             swim,
+	}, parts[4:], nil
+}
+
+// info возвращает строки отчёта о заплыве: стиль, бассейн и темп на 100 м.
+func (s Swim) info(duration time.Duration) string {
+	pace := time.Duration(float64(duration) * swimPaceDistance / (float64(s.Laps) * s.PoolLength))
+	return fmt.Sprintf("Стиль: %s\nБассейн: %.0f м, кругов: %d\nТемп: %s на 100 м\n",
+		s.Stroke, s.PoolLength, s.Laps, formatClock(pace, false))
+}
+
+// SwimmingSpentCalories возвращает количество калорий, потраченных на заплыв:
+// MET стиля, умноженный на вес и часы.
+func SwimmingSpentCalories(stroke string, weight float64, duration time.Duration) (float64, error) {
+	return defaultCalculator.SwimmingSpentCalories(stroke, weight, duration)
+}
+
+// SwimmingSpentCalories возвращает калории заплыва.
+func (c Calculator) SwimmingSpentCalories(stroke string, weight float64, duration time.Duration) (float64, error) {
+	met, ok := strokeMET[stroke]
+	switch {
+	case !ok:
+		return 0, parseerr.New("stroke", parseerr.KindUnknown, "неизвестный стиль плавания: %q", stroke)
+	case weight <= 0:
+		return 0, parseerr.New("weight", parseerr.KindRange, "вес должен быть больше нуля: %.2f", weight)
+	case duration <= 0:
+		return 0, parseerr.New("duration", parseerr.KindRange, "продолжительность должна быть больше нуля: %s", duration)
+	}
+	return met * weight * duration.Hours(), nil
+}
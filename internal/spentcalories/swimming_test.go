@@ -0,0 +1,84 @@
+package spentcalories
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *SpentCaloriesTestSuite) TestParseSwim() {
+	tests := []struct {
+		name    string
+		input   string
+		want    Segment
+		wantErr bool
+	}{
+		{
+			name:  "кроль",
+			input: "40x25m,Плавание,кроль,0h45m,hr=130",
+			want: Segment{
+				Type:             SwimmingType,
+				Duration:         45 * time.Minute,
+				RecordedDistance: 1,
+				Swim:             Swim{Laps: 40, PoolLength: 25, Stroke: "кроль"},
+				HeartRate:        130,
+			},
+		},
+		{
+			name:    "нет стиля",
+			input:   "40x25m,Плавание,0h45m",
+			wantErr: true,
+		},
+		{
+			name:    "неизвестный стиль",
+			input:   "40x25m,Плавание,по-собачьи,0h45m",
+			wantErr: true,
+		},
+		{
+			name:    "нет единиц длины бассейна",
+			input:   "40x25,Плавание,кроль,0h45m",
+			wantErr: true,
+		},
+		{
+			name:    "ноль кругов",
+			input:   "0x25m,Плавание,кроль,0h45m",
+			wantErr: true,
+		},
+		{
+			name:    "рельеф у заплыва",
+			input:   "40x25m,Плавание,кроль,0h45m,gain=10",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			got, err := parseRecord(tt.input)
+			if tt.wantErr {
+				assert.Error(suite.T(), err)
+				return
+			}
+			assert.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tt.want, got)
+		})
+	}
+}
+
+func (suite *SpentCaloriesTestSuite) TestSwimmingSpentCalories() {
+	got, err := SwimmingSpentCalories("брасс", 70, 30*time.Minute)
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 360.5, got, 1e-9)
+
+	_, err = SwimmingSpentCalories("вольный", 70, time.Hour)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *SpentCaloriesTestSuite) TestTrainingInfoSwimming() {
+	got, err := TrainingInfo("60x25m,Плавание,кроль,0h45m", 70, 1.75)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Тип тренировки: Плавание\nДлительность: 0.75 ч.\nДистанция: 1.50 км.\nСкорость: 2.00 км/ч\n"+
+		"Стиль: кроль\nБассейн: 25 м, кругов: 60\nТемп: 3:00 на 100 м\nСожгли калорий: 435.75\n", got)
+
+	_, err = TrainingInfo("6000,Плавание,1h00m", 70, 1.75)
+	assert.ErrorContains(suite.T(), err, "40x25m,Плавание,кроль,0h45m")
+}
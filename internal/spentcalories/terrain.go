@@ -0,0 +1,150 @@
+package spentcalories
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/parseerr"
+)
+
+// Коэффициенты уравнений ACSM для потребления кислорода (мл/кг/мин)
+// в зависимости от скорости S (м/мин) и уклона G (доля):
+// ходьба — 0.1·S + 1.8·S·G + 3.5, бег — 0.2·S + 0.9·S·G + 3.5.
+const (
+	restingVO2           = 3.5 // потребление кислорода в покое.
+	walkingHorizontalVO2 = 0.1 // горизонтальная составляющая при ходьбе.
+	walkingVerticalVO2   = 1.8 // вертикальная составляющая при ходьбе.
+	runningHorizontalVO2 = 0.2 // горизонтальная составляющая при беге.
+	runningVerticalVO2   = 0.9 // вертикальная составляющая при беге.
+	percent              = 100 // количество процентов в единице.
+)
+
+// Terrain описывает рельеф тренировки. Нулевое значение соответствует равнине.
+// Уравнения ACSM не учитывают спуск, поэтому сброс высоты и отрицательный уклон
+// не уменьшают расход калорий и сохраняются только для отчёта.
+type Terrain struct {
+	Gain  float64 // набор высоты, м.
+	Loss  float64 // сброс высоты, м.
+	Grade float64 // средний уклон, %.
+}
+
+// parseTerrain разбирает параметры рельефа вида "gain=120", "loss=80", "grade=4.5".
+func parseTerrain(fields []string) (Terrain, error) {
+	var t Terrain
+	seen := make(map[string]bool, len(fields))
+
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return Terrain{}, parseerr.New("", parseerr.KindFormat, "неверный параметр тренировки %q: ожидается \"ключ=значение\"", field)
+		}
+		if seen[key] {
+			return Terrain{}, parseerr.New(key, parseerr.KindConflict, "параметр тренировки %q указан повторно", key)
+		}
+		seen[key] = true
+
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return Terrain{}, parseerr.New(key, parseerr.KindSyntax, "некорректное значение параметра %q: %w", key, err)
+		}
+
+		switch key {
+		case "gain":
+			if v < 0 {
+				return Terrain{}, parseerr.New(key, parseerr.KindRange, "набор высоты не может быть отрицательным: %.1f", v)
+			}
+			t.Gain = v
+		case "loss":
+			if v < 0 {
+				return Terrain{}, parseerr.New(key, parseerr.KindRange, "сброс высоты не может быть отрицательным: %.1f", v)
+			}
+			t.Loss = v
+		case "grade":
+			t.Grade = v
+		default:
+			return Terrain{}, parseerr.New(key, parseerr.KindUnknown, "неизвестный параметр тренировки: %q", key)
+		}
+	}
+
+	if seen["grade"] && (seen["gain"] || seen["loss"]) {
+		return Terrain{}, parseerr.New("grade", parseerr.KindConflict, "укажите либо набор и сброс высоты, либо уклон")
+	}
+
+	return t, nil
+}
+
+// climb возвращает высоту подъёма в метрах на дистанции dist (км).
+// Набор высоты имеет приоритет над средним уклоном.
+func (t Terrain) climb(dist float64) float64 {
+	if t.Gain > 0 {
+		return t.Gain
+	}
+	if t.Grade > 0 {
+		return t.Grade / percent * dist * mInKm
+	}
+	return 0
+}
+
+// info возвращает строки отчёта о рельефе. Для равнины возвращает пустую строку.
+func (t Terrain) info() string {
+	var b strings.Builder
+	if t.Gain > 0 {
+		fmt.Fprintf(&b, "Набор высоты: %.0f м.\n", t.Gain)
+	}
+	if t.Loss > 0 {
+		fmt.Fprintf(&b, "Сброс высоты: %.0f м.\n", t.Loss)
+	}
+	if t.Grade != 0 {
+		fmt.Fprintf(&b, "Уклон: %.1f%%\n", t.Grade)
+	}
+	return b.String()
+}
+
+// gradeFactor возвращает во сколько раз подъём climb (м) увеличивает расход энергии
+// по сравнению с равниной при тех же скорости и продолжительности.
+func gradeFactor(horizontalVO2, verticalVO2, speed float64, duration time.Duration, climb float64) float64 {
+	if climb <= 0 {
+		return 1
+	}
+	speedMPerMin := speed * mInKm / minInH
+	flat := (horizontalVO2*speedMPerMin + restingVO2) * duration.Minutes()
+	return 1 + verticalVO2*climb/flat
+}
+
+// WalkingSpentCaloriesOnTerrain возвращает количество калорий, потраченных при ходьбе
+// с учётом рельефа. На равнине совпадает с WalkingSpentCalories.
+func WalkingSpentCaloriesOnTerrain(steps int, weight, height float64, duration time.Duration, terrain Terrain) (float64, error) {
+	return defaultCalculator.WalkingSpentCaloriesOnTerrain(steps, weight, height, duration, terrain)
+}
+
+// WalkingSpentCaloriesOnTerrain возвращает калории при ходьбе по рельефу с коэффициентами калькулятора.
+func (c Calculator) WalkingSpentCaloriesOnTerrain(steps int, weight, height float64, duration time.Duration, terrain Terrain) (float64, error) {
+	calories, err := c.WalkingSpentCalories(steps, weight, height, duration)
+	if err != nil {
+		return 0, err
+	}
+
+	climb := terrain.climb(c.distance(steps, height))
+	factor := gradeFactor(walkingHorizontalVO2, walkingVerticalVO2, c.meanSpeed(steps, height, duration), duration, climb)
+	return calories * factor, nil
+}
+
+// RunningSpentCaloriesOnTerrain возвращает количество калорий, потраченных при беге
+// с учётом рельефа. На равнине совпадает с RunningSpentCalories.
+func RunningSpentCaloriesOnTerrain(steps int, weight, height float64, duration time.Duration, terrain Terrain) (float64, error) {
+	return defaultCalculator.RunningSpentCaloriesOnTerrain(steps, weight, height, duration, terrain)
+}
+
+// RunningSpentCaloriesOnTerrain возвращает калории при беге по рельефу с коэффициентами калькулятора.
+func (c Calculator) RunningSpentCaloriesOnTerrain(steps int, weight, height float64, duration time.Duration, terrain Terrain) (float64, error) {
+	calories, err := c.RunningSpentCalories(steps, weight, height, duration)
+	if err != nil {
+		return 0, err
+	}
+
+	climb := terrain.climb(c.distance(steps, height))
+	factor := gradeFactor(runningHorizontalVO2, runningVerticalVO2, c.meanSpeed(steps, height, duration), duration, climb)
+	return calories * factor, nil
+}
@@ -0,0 +1,111 @@
+package spentcalories
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *SpentCaloriesTestSuite) TestParseTerrain() {
+	tests := []struct {
+		name    string
+		fields  []string
+		want    Terrain
+		wantErr bool
+	}{
+		{
+			name:   "без параметров",
+			fields: nil,
+			want:   Terrain{},
+		},
+		{
+			name:   "набор и сброс высоты",
+			fields: []string{"gain=420", "loss=380"},
+			want:   Terrain{Gain: 420, Loss: 380},
+		},
+		{
+			name:   "уклон",
+			fields: []string{"grade=4.5"},
+			want:   Terrain{Grade: 4.5},
+		},
+		{
+			name:    "нет знака равенства",
+			fields:  []string{"extra"},
+			wantErr: true,
+		},
+		{
+			name:    "неизвестный параметр",
+			fields:  []string{"hr=150"},
+			wantErr: true,
+		},
+		{
+			name:    "отрицательный набор высоты",
+			fields:  []string{"gain=-10"},
+			wantErr: true,
+		},
+		{
+			name:    "повторный параметр",
+			fields:  []string{"gain=10", "gain=20"},
+			wantErr: true,
+		},
+		{
+			name:    "уклон вместе с набором высоты",
+			fields:  []string{"gain=10", "grade=2"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			got, err := parseTerrain(tt.fields)
+
+			if tt.wantErr {
+				assert.Error(suite.T(), err)
+				return
+			}
+
+			assert.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tt.want, got)
+		})
+	}
+}
+
+func (suite *SpentCaloriesTestSuite) TestSpentCaloriesOnTerrain() {
+	flatWalk, _ := WalkingSpentCalories(6000, 75, 1.75, time.Hour)
+	flatRun, _ := RunningSpentCalories(6000, 75, 1.75, time.Hour)
+
+	walk, err := WalkingSpentCaloriesOnTerrain(6000, 75, 1.75, time.Hour, Terrain{})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), flatWalk, walk, "на равнине расход должен совпадать с WalkingSpentCalories")
+
+	// 4.725 км/ч = 78.75 м/мин; равнина: (0.1·78.75 + 3.5)·60 = 682.5 мл/кг.
+	// Подъём 300 м: 1.8·300 = 540 мл/кг, множитель 1 + 540/682.5.
+	walk, err = WalkingSpentCaloriesOnTerrain(6000, 75, 1.75, time.Hour, Terrain{Gain: 300, Loss: 300})
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), flatWalk*(1+540/682.5), walk, 0.01)
+
+	// Уклон 5% на 4.725 км — это 236.25 м подъёма.
+	byGrade, err := RunningSpentCaloriesOnTerrain(6000, 75, 1.75, time.Hour, Terrain{Grade: 5})
+	assert.NoError(suite.T(), err)
+	byGain, err := RunningSpentCaloriesOnTerrain(6000, 75, 1.75, time.Hour, Terrain{Gain: 236.25})
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), byGain, byGrade, 0.001)
+	assert.Greater(suite.T(), byGrade, flatRun)
+
+	downhill, err := RunningSpentCaloriesOnTerrain(6000, 75, 1.75, time.Hour, Terrain{Grade: -5})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), flatRun, downhill, "спуск не должен уменьшать расход")
+
+	_, err = WalkingSpentCaloriesOnTerrain(0, 75, 1.75, time.Hour, Terrain{Gain: 100})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *SpentCaloriesTestSuite) TestTrainingInfoWithTerrain() {
+	got, err := TrainingInfo("6000,Ходьба,1h00m,gain=300,loss=300", 75, 1.75)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Тип тренировки: Ходьба\nДлительность: 1.00 ч.\nДистанция: 4.72 км.\nСкорость: 4.72 км/ч\n"+
+		"Набор высоты: 300 м.\nСброс высоты: 300 м.\nСожгли калорий: 317.38\n", got)
+
+	_, err = TrainingInfo("6000,Ходьба,1h00m,extra", 75, 1.75)
+	assert.Error(suite.T(), err)
+}
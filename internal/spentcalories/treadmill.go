@@ -0,0 +1,99 @@
+package spentcalories
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/parseerr"
+)
+
+// Treadmill — показания беговой дорожки. Нулевое значение означает тренировку
+// не на дорожке: скорость и дистанция тогда рассчитываются по шагам и росту.
+type Treadmill struct {
+	Speed   float64 // скорость полотна, км/ч.
+	Incline float64 // наклон полотна, %.
+}
+
+// parseTreadmill дополняет показания дорожки t параметром key: "belt" — скорость
+// полотна в км/ч или "incline" — наклон в процентах. Повторы параметров
+// проверяет вызывающий.
+func parseTreadmill(t *Treadmill, key, value string) error {
+	switch key {
+	case "belt":
+		speed, err := parsePositive(key, "скорость полотна", value)
+		if err != nil {
+			return err
+		}
+		t.Speed = speed
+	case "incline":
+		incline, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return parseerr.New(key, parseerr.KindSyntax, "некорректный наклон полотна: %w", err)
+		}
+		if incline < 0 {
+			return parseerr.New(key, parseerr.KindRange, "наклон полотна не может быть отрицательным: %.1f", incline)
+		}
+		t.Incline = incline
+	}
+	return nil
+}
+
+// terrain возвращает рельеф, равносильный наклону полотна.
+func (t Treadmill) terrain() Terrain {
+	return Terrain{Grade: t.Incline}
+}
+
+// info возвращает строки отчёта о дорожке: её скорость и наклон,
+// а рядом для сверки — скорость, рассчитанную по шагам stepSpeed (км/ч).
+func (t Treadmill) info(stepSpeed float64) string {
+	return fmt.Sprintf("Беговая дорожка: %.2f км/ч, наклон %.1f%%\nСкорость по шагам: %.2f км/ч\n",
+		t.Speed, t.Incline, stepSpeed)
+}
+
+// validateTreadmill проверяет вес, скорость полотна и продолжительность.
+func validateTreadmill(weight, speed float64, duration time.Duration) error {
+	switch {
+	case weight <= 0:
+		return parseerr.New("weight", parseerr.KindRange, "вес должен быть больше нуля: %.2f", weight)
+	case speed <= 0:
+		return parseerr.New("belt", parseerr.KindRange, "скорость полотна должна быть больше нуля: %.2f", speed)
+	case duration <= 0:
+		return parseerr.New("duration", parseerr.KindRange, "продолжительность должна быть больше нуля: %s", duration)
+	}
+	return nil
+}
+
+// WalkingSpentCaloriesOnTreadmill возвращает количество калорий, потраченных при ходьбе
+// по дорожке со скоростью полотна и наклоном t. Шаги и рост не используются.
+func WalkingSpentCaloriesOnTreadmill(t Treadmill, weight float64, duration time.Duration) (float64, error) {
+	return defaultCalculator.WalkingSpentCaloriesOnTreadmill(t, weight, duration)
+}
+
+// WalkingSpentCaloriesOnTreadmill возвращает калории при ходьбе по дорожке с коэффициентами калькулятора.
+func (c Calculator) WalkingSpentCaloriesOnTreadmill(t Treadmill, weight float64, duration time.Duration) (float64, error) {
+	if err := validateTreadmill(weight, t.Speed, duration); err != nil {
+		return 0, err
+	}
+
+	calories := weight * t.Speed * duration.Minutes() / minInH * c.cfg.WalkingCaloriesCoefficient
+	climb := t.terrain().climb(t.Speed * duration.Hours())
+	return calories * gradeFactor(walkingHorizontalVO2, walkingVerticalVO2, t.Speed, duration, climb), nil
+}
+
+// RunningSpentCaloriesOnTreadmill возвращает количество калорий, потраченных при беге
+// по дорожке со скоростью полотна и наклоном t. Шаги и рост не используются.
+func RunningSpentCaloriesOnTreadmill(t Treadmill, weight float64, duration time.Duration) (float64, error) {
+	return defaultCalculator.RunningSpentCaloriesOnTreadmill(t, weight, duration)
+}
+
+// RunningSpentCaloriesOnTreadmill возвращает калории при беге по дорожке с коэффициентами калькулятора.
+func (c Calculator) RunningSpentCaloriesOnTreadmill(t Treadmill, weight float64, duration time.Duration) (float64, error) {
+	if err := validateTreadmill(weight, t.Speed, duration); err != nil {
+		return 0, err
+	}
+
+	calories := weight * t.Speed * duration.Minutes() / minInH
+	climb := t.terrain().climb(t.Speed * duration.Hours())
+	return calories * gradeFactor(runningHorizontalVO2, runningVerticalVO2, t.Speed, duration, climb), nil
+}
@@ -0,0 +1,68 @@
+package spentcalories
+
+import (
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *SpentCaloriesTestSuite) TestParseRecordTreadmill() {
+	got, err := parseRecord("6000,Бег,1h00m,belt=10,incline=2,hr=150")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), Treadmill{Speed: 10, Incline: 2}, got.Treadmill)
+	assert.Equal(suite.T(), Terrain{}, got.Terrain)
+	assert.Equal(suite.T(), 150.0, got.HeartRate)
+
+	got, err = parseRecord("6000,Ходьба,1h00m,belt=5,incline=0")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), Treadmill{Speed: 5}, got.Treadmill)
+
+	for _, data := range []string{
+		"6000,Бег,1h00m,incline=2",
+		"6000,Бег,1h00m,belt=0",
+		"6000,Бег,1h00m,belt=x",
+		"6000,Бег,1h00m,belt=10,belt=11",
+		"6000,Бег,1h00m,belt=10,incline=0,incline=5",
+		"6000,Бег,1h00m,belt=10,incline=5,incline=0",
+		"6000,Бег,1h00m,belt=10,incline=-1",
+		"6000,Бег,1h00m,belt=10,grade=2",
+		"25km,Велосипед,1h00m,belt=10",
+	} {
+		_, err := parseRecord(data)
+		assert.Error(suite.T(), err, data)
+	}
+}
+
+func (suite *SpentCaloriesTestSuite) TestSpentCaloriesOnTreadmill() {
+	// На ровном полотне калории считаются по формуле для равнины со скоростью полотна.
+	got, err := RunningSpentCaloriesOnTreadmill(Treadmill{Speed: 10}, 75, time.Hour)
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 750, got, 1e-9)
+
+	// Наклон 2% на 10 км — подъём на 200 м.
+	got, err = RunningSpentCaloriesOnTreadmill(Treadmill{Speed: 10, Incline: 2}, 75, time.Hour)
+	assert.NoError(suite.T(), err)
+	flat := (0.2*10000.0/60 + 3.5) * 60
+	assert.InDelta(suite.T(), 750*(1+0.9*200/flat), got, 1e-9)
+
+	got, err = WalkingSpentCaloriesOnTreadmill(Treadmill{Speed: 5}, 75, time.Hour)
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 375*config.Default().WalkingCaloriesCoefficient, got, 1e-9)
+
+	_, err = WalkingSpentCaloriesOnTreadmill(Treadmill{}, 75, time.Hour)
+	assert.Error(suite.T(), err)
+	_, err = RunningSpentCaloriesOnTreadmill(Treadmill{Speed: 10}, 0, time.Hour)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *SpentCaloriesTestSuite) TestTrainingInfoTreadmill() {
+	got, err := TrainingInfo("6000,Бег,1h00m,belt=10", 75, 1.75)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Тип тренировки: Бег\nДлительность: 1.00 ч.\nДистанция: 10.00 км.\nСкорость: 10.00 км/ч\n"+
+		"Беговая дорожка: 10.00 км/ч, наклон 0.0%\nСкорость по шагам: 4.72 км/ч\nСожгли калорий: 750.00\n", got)
+
+	pace, err := PaceInfo("6000,Бег,1h00m,belt=10", 75, 1.75, Riegel(1))
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), pace, "Темп: 6:00 мин/км\n")
+}
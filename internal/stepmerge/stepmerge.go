@@ -0,0 +1,147 @@
+package stepmerge
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Packet — пакет дневной активности с моментом начала и источником данных.
+type Packet struct {
+	Start    time.Time
+	Duration time.Duration
+	Steps    int
+	Source   string
+}
+
+// end возвращает момент окончания пакета.
+func (p Packet) end() time.Time {
+	return p.Start.Add(p.Duration)
+}
+
+// rate возвращает шаги пакета в единицу времени.
+func (p Packet) rate() float64 {
+	return float64(p.Steps) / float64(p.Duration)
+}
+
+// Piece — отрезок итоговой шкалы времени, шаги которого взяты из одного пакета.
+type Piece struct {
+	Start, End time.Time
+	// Packet — индекс пакета во входных данных Merge.
+	Packet int
+	Source string
+	Steps  int
+}
+
+// Result — итог объединения пакетов.
+type Result struct {
+	// Pieces — отрезки с шагами, упорядоченные по времени.
+	Pieces []Piece
+	Steps  int
+	// BySource — шаги итога по источникам.
+	BySource map[string]int
+	// Duplicates — шаги пакетов, не вошедшие в итог как повторные.
+	Duplicates int
+}
+
+// Merger объединяет пакеты дневной активности из нескольких источников,
+// например телефона и часов, без двойного учёта шагов на пересекающихся
+// отрезках времени.
+// Нулевое значение берёт на каждом пересечении пакет с наибольшей частотой
+// шагов, то есть насчитавший на общем отрезке больше шагов.
+type Merger struct {
+	rank map[string]int
+}
+
+// New возвращает объединитель с приоритетом источников priority: на пересечении
+// побеждает источник, указанный раньше. Источники не из списка уступают
+// перечисленным, а между собой и при пустом списке сравниваются по частоте шагов.
+func New(priority []string) Merger {
+	m := Merger{rank: make(map[string]int, len(priority))}
+	for i, source := range priority {
+		if _, ok := m.rank[source]; !ok {
+			m.rank[source] = i
+		}
+	}
+	return m
+}
+
+// rankOf возвращает место источника в приоритете: чем меньше, тем важнее.
+func (m Merger) rankOf(source string) int {
+	if r, ok := m.rank[source]; ok {
+		return r
+	}
+	return len(m.rank)
+}
+
+// better сообщает, предпочтительнее ли пакет p пакета q на общем отрезке.
+func (m Merger) better(p, q Packet) bool {
+	if rp, rq := m.rankOf(p.Source), m.rankOf(q.Source); rp != rq {
+		return rp < rq
+	}
+	return p.rate() > q.rate()
+}
+
+// Merge объединяет пакеты packets. Шкала времени делится на отрезки границами
+// пакетов; на каждом отрезке из пересекающихся пакетов выбирается один,
+// а его шаги делятся пропорционально продолжительности. Пакеты
+// с неположительными шагами или продолжительностью пропускаются.
+func (m Merger) Merge(packets []Packet) Result {
+	var bounds []time.Time
+	for _, p := range packets {
+		if p.Steps > 0 && p.Duration > 0 {
+			bounds = append(bounds, p.Start, p.end())
+		}
+	}
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i].Before(bounds[j]) })
+
+	res := Result{BySource: make(map[string]int)}
+	var (
+		total    float64 // шаги нарастающим итогом.
+		assigned int     // шаги, уже распределённые по отрезкам.
+	)
+	for i := 1; i < len(bounds); i++ {
+		from, to := bounds[i-1], bounds[i]
+		if !from.Before(to) {
+			continue
+		}
+
+		best := -1
+		for j, p := range packets {
+			if p.Steps <= 0 || p.Duration <= 0 || p.Start.After(from) || p.end().Before(to) {
+				continue
+			}
+			if best < 0 || m.better(p, packets[best]) {
+				best = j
+			}
+		}
+		if best < 0 {
+			continue
+		}
+
+		// Округляем нарастающим итогом, чтобы отрезки одного пакета в сумме давали его шаги.
+		total += packets[best].rate() * float64(to.Sub(from))
+		steps := int(math.Round(total)) - assigned
+		assigned += steps
+
+		if n := len(res.Pieces); n > 0 && res.Pieces[n-1].Packet == best && res.Pieces[n-1].End.Equal(from) {
+			res.Pieces[n-1].End = to
+			res.Pieces[n-1].Steps += steps
+		} else {
+			res.Pieces = append(res.Pieces, Piece{Start: from, End: to, Packet: best, Source: packets[best].Source, Steps: steps})
+		}
+	}
+
+	sum := 0
+	for _, p := range packets {
+		if p.Steps > 0 && p.Duration > 0 {
+			sum += p.Steps
+		}
+	}
+	for _, piece := range res.Pieces {
+		res.Steps += piece.Steps
+		res.BySource[piece.Source] += piece.Steps
+	}
+	res.Duplicates = sum - res.Steps
+	return res
+}
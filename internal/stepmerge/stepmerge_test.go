@@ -0,0 +1,78 @@
+package stepmerge
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type StepMergeTestSuite struct {
+	suite.Suite
+}
+
+func TestStepMergeSuite(t *testing.T) {
+	suite.Run(t, new(StepMergeTestSuite))
+}
+
+// at возвращает момент 19.10.2026 в h часов m минут.
+func at(h, m int) time.Time {
+	return time.Date(2026, 10, 19, h, m, 0, 0, time.UTC)
+}
+
+func (suite *StepMergeTestSuite) TestMergeWithoutOverlap() {
+	got := Merger{}.Merge([]Packet{
+		{Start: at(8, 0), Duration: time.Hour, Steps: 3000, Source: "phone"},
+		{Start: at(12, 0), Duration: 30 * time.Minute, Steps: 1001, Source: "watch"},
+	})
+	assert.Equal(suite.T(), 4001, got.Steps)
+	assert.Zero(suite.T(), got.Duplicates)
+	assert.Equal(suite.T(), map[string]int{"phone": 3000, "watch": 1001}, got.BySource)
+	assert.Equal(suite.T(), []Piece{
+		{Start: at(8, 0), End: at(9, 0), Packet: 0, Source: "phone", Steps: 3000},
+		{Start: at(12, 0), End: at(12, 30), Packet: 1, Source: "watch", Steps: 1001},
+	}, got.Pieces)
+}
+
+func (suite *StepMergeTestSuite) TestMergeMax() {
+	// Одна прогулка 8:00–9:00 пришла с часов и частично, 8:30–9:30, с телефона.
+	packets := []Packet{
+		{Start: at(8, 0), Duration: time.Hour, Steps: 6000, Source: "watch"},
+		{Start: at(8, 30), Duration: time.Hour, Steps: 4000, Source: "phone"},
+	}
+	got := Merger{}.Merge(packets)
+	assert.Equal(suite.T(), 8000, got.Steps)
+	assert.Equal(suite.T(), 2000, got.Duplicates)
+	assert.Equal(suite.T(), map[string]int{"watch": 6000, "phone": 2000}, got.BySource)
+	assert.Equal(suite.T(), []Piece{
+		{Start: at(8, 0), End: at(9, 0), Packet: 0, Source: "watch", Steps: 6000},
+		{Start: at(9, 0), End: at(9, 30), Packet: 1, Source: "phone", Steps: 2000},
+	}, got.Pieces)
+}
+
+func (suite *StepMergeTestSuite) TestMergePriority() {
+	packets := []Packet{
+		{Start: at(8, 0), Duration: time.Hour, Steps: 6000, Source: "watch"},
+		{Start: at(8, 30), Duration: time.Hour, Steps: 4000, Source: "phone"},
+	}
+	got := New([]string{"phone", "watch"}).Merge(packets)
+	assert.Equal(suite.T(), 7000, got.Steps)
+	assert.Equal(suite.T(), map[string]int{"watch": 3000, "phone": 4000}, got.BySource)
+	assert.Len(suite.T(), got.Pieces, 2)
+
+	// Источник не из списка уступает перечисленным, даже насчитав больше шагов;
+	// с 9:00 до 9:30 остаются телефон и браслет вне списка, и берётся браслет.
+	packets = append(packets, Packet{Start: at(8, 0), Duration: 90 * time.Minute, Steps: 20000, Source: "band"})
+	got = New([]string{"watch"}).Merge(packets)
+	assert.Equal(suite.T(), map[string]int{"watch": 6000, "band": 6667}, got.BySource)
+}
+
+func (suite *StepMergeTestSuite) TestMergeSkipsInvalid() {
+	got := Merger{}.Merge([]Packet{
+		{Start: at(8, 0), Duration: 0, Steps: 100},
+		{Start: at(9, 0), Duration: time.Hour, Steps: 0},
+	})
+	assert.Zero(suite.T(), got.Steps)
+	assert.Empty(suite.T(), got.Pieces)
+}
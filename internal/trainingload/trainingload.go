@@ -0,0 +1,146 @@
+package trainingload
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+)
+
+// Постоянные времени экспоненциального сглаживания, дней.
+const (
+	chronicDays = 42 // хроническая нагрузка (CTL, «форма»).
+	acuteDays   = 7  // острая нагрузка (ATL, «усталость»).
+)
+
+// dateLayout — формат даты в таблице нагрузки.
+const dateLayout = "02.01.2006"
+
+// Thresholds — пороговые значения спортсмена, относительно которых оценивается интенсивность.
+type Thresholds struct {
+	// Speed — пороговая скорость по типу тренировки, км/ч: скорость,
+	// которую можно держать около часа.
+	Speed map[string]float64
+	// HeartRate — пороговый пульс, уд/мин; 0 — оценивать только по скорости.
+	HeartRate float64
+	// RestingHeartRate — пульс в покое, уд/мин.
+	RestingHeartRate float64
+}
+
+// DefaultThresholds возвращает пороги любителя среднего уровня.
+func DefaultThresholds() Thresholds {
+	return Thresholds{
+		Speed: map[string]float64{
+			spentcalories.RunningType: 12,
+			spentcalories.WalkingType: 6.5,
+			spentcalories.CyclingType: 28,
+			// 2:00 на 100 м.
+			spentcalories.SwimmingType: 3,
+		},
+		HeartRate:        170,
+		RestingHeartRate: 60,
+	}
+}
+
+// intensity возвращает долю порога для отрезка: по пульсу, если он измерен,
+// иначе по средней скорости. Для типа без пороговой скорости возвращает 0.
+func (t Thresholds) intensity(seg spentcalories.SegmentResult) float64 {
+	if seg.HeartRate > 0 && t.HeartRate > t.RestingHeartRate {
+		return max(0, (seg.HeartRate-t.RestingHeartRate)/(t.HeartRate-t.RestingHeartRate))
+	}
+	threshold := t.Speed[seg.Type]
+	if threshold <= 0 {
+		return 0
+	}
+	return seg.Speed / threshold
+}
+
+// Score возвращает нагрузку тренировки: сумму по отрезкам часов, умноженных
+// на квадрат доли порога, умноженную на 100. Час на пороге даёт 100 единиц.
+func (t Thresholds) Score(r spentcalories.SessionResult) float64 {
+	var score float64
+	for _, seg := range r.Segments {
+		f := t.intensity(seg)
+		score += seg.Duration.Hours() * f * f * 100
+	}
+	return score
+}
+
+// Status — вид дня в модели нагрузки.
+type Status string
+
+// Виды дней.
+const (
+	StatusTraining Status = "тренировка" // день с тренировками.
+	StatusRest     Status = "отдых"      // запланированный день отдыха без тренировок.
+	StatusMissed   Status = "пропуск"    // день без тренировок и без запланированного отдыха.
+)
+
+// Day — нагрузка и состояние спортсмена за день.
+type Day struct {
+	Date time.Time
+	// Status отличает запланированный отдых от пропущенной тренировки:
+	// нагрузка обоих равна нулю, но пропуск означает отступление от плана.
+	Status Status
+	Load   float64 // суммарная нагрузка тренировок дня.
+	CTL    float64 // хроническая нагрузка после дня.
+	ATL    float64 // острая нагрузка после дня.
+	// TSB — баланс на утро дня: CTL минус ATL после предыдущего дня.
+	// Отрицательный баланс означает накопленную усталость, положительный — свежесть.
+	TSB float64
+}
+
+// Model рассчитывает нагрузку, CTL, ATL и TSB по дням отчёта r.
+// Отчёт должен начинаться с первого дня истории: до него нагрузка считается нулевой.
+// Запланированный отдых и пропуск намеренно считаются одинаково: нагрузка обоих
+// равна нулю, а CTL и ATL описывают реакцию организма на нагрузку, которая
+// не зависит от того, был ли день без тренировок запланирован. Различие между
+// ними модель передаёт только в Status.
+func Model(r report.Report, t Thresholds) []Day {
+	days := make([]Day, 0, len(r.Days))
+	var ctl, atl float64
+	for _, d := range r.Days {
+		day := Day{Date: d.Date, Status: status(d), TSB: ctl - atl}
+		for _, tr := range d.Trainings {
+			day.Load += t.Score(tr.Result)
+		}
+		ctl += (day.Load - ctl) / chronicDays
+		atl += (day.Load - atl) / acuteDays
+		day.CTL, day.ATL = ctl, atl
+		days = append(days, day)
+	}
+	return days
+}
+
+// status возвращает вид дня отчёта d.
+func status(d report.Day) Status {
+	switch {
+	case len(d.Trainings) > 0:
+		return StatusTraining
+	case d.Rest != nil:
+		return StatusRest
+	default:
+		return StatusMissed
+	}
+}
+
+// Text возвращает таблицу нагрузки по дням с видом каждого дня.
+func Text(days []Day) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-10s %8s %6s %6s %6s  %s\n", "Дата", "Нагрузка", "CTL", "ATL", "TSB", "День")
+	for _, d := range days {
+		fmt.Fprintf(&b, "%-10s %8.0f %6.1f %6.1f %6.1f  %s\n", d.Date.Format(dateLayout), d.Load, d.CTL, d.ATL, roundZero(d.TSB), d.Status)
+	}
+	return b.String()
+}
+
+// roundZero заменяет близкие к нулю значения нулём, чтобы не печатать "-0.0".
+func roundZero(v float64) float64 {
+	if math.Abs(v) < 0.05 {
+		return 0
+	}
+	return v
+}
@@ -0,0 +1,109 @@
+package trainingload
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/recovery"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type TrainingLoadTestSuite struct {
+	suite.Suite
+}
+
+func TestTrainingLoadSuite(t *testing.T) {
+	suite.Run(t, new(TrainingLoadTestSuite))
+}
+
+// session возвращает тренировку из одного отрезка.
+func session(seg spentcalories.SegmentResult) spentcalories.SessionResult {
+	return spentcalories.SessionResult{Segments: []spentcalories.SegmentResult{seg}}
+}
+
+func (suite *TrainingLoadTestSuite) TestScore() {
+	th := DefaultThresholds()
+
+	atThreshold := session(spentcalories.SegmentResult{
+		Segment: spentcalories.Segment{Type: spentcalories.RunningType, Duration: time.Hour},
+		Speed:   12,
+	})
+	assert.InDelta(suite.T(), 100, th.Score(atThreshold), 1e-9)
+
+	easy := session(spentcalories.SegmentResult{
+		Segment: spentcalories.Segment{Type: spentcalories.RunningType, Duration: 2 * time.Hour},
+		Speed:   9,
+	})
+	assert.InDelta(suite.T(), 112.5, th.Score(easy), 1e-9)
+
+	// Пульс важнее скорости: 115 уд/мин — половина резерва между покоем и порогом.
+	byHR := session(spentcalories.SegmentResult{
+		Segment: spentcalories.Segment{Type: spentcalories.RunningType, Duration: time.Hour, HeartRate: 115},
+		Speed:   12,
+	})
+	assert.InDelta(suite.T(), 25, th.Score(byHR), 1e-9)
+
+	unknown := session(spentcalories.SegmentResult{Segment: spentcalories.Segment{Type: "Йога", Duration: time.Hour}, Speed: 3})
+	assert.Zero(suite.T(), th.Score(unknown))
+}
+
+func (suite *TrainingLoadTestSuite) TestModel() {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	r := report.Report{From: from}
+	for i := range 3 {
+		r.Days = append(r.Days, report.Day{Date: from.AddDate(0, 0, i)})
+	}
+	r.Days[0].Trainings = []report.Training{{Result: session(spentcalories.SegmentResult{
+		Segment: spentcalories.Segment{Type: spentcalories.RunningType, Duration: time.Hour},
+		Speed:   12,
+	})}}
+	r.Days[1].Rest = &recovery.Rest{Note: "восстановление"}
+
+	got := Model(r, DefaultThresholds())
+	suite.Require().Len(got, 3)
+	assert.InDelta(suite.T(), 100, got[0].Load, 1e-9)
+	assert.InDelta(suite.T(), 100.0/42, got[0].CTL, 1e-9)
+	assert.InDelta(suite.T(), 100.0/7, got[0].ATL, 1e-9)
+	assert.Zero(suite.T(), got[0].TSB)
+
+	assert.InDelta(suite.T(), got[0].CTL-got[0].ATL, got[1].TSB, 1e-9)
+	assert.InDelta(suite.T(), got[0].ATL*6/7, got[1].ATL, 1e-9)
+	assert.InDelta(suite.T(), got[0].CTL*41/42, got[1].CTL, 1e-9)
+	assert.Greater(suite.T(), got[2].TSB, got[1].TSB, "усталость спадает быстрее формы")
+	assert.Equal(suite.T(), []Status{StatusTraining, StatusRest, StatusMissed}, []Status{got[0].Status, got[1].Status, got[2].Status})
+
+	text := Text(got)
+	assert.True(suite.T(), strings.HasPrefix(text, "Дата"))
+	assert.Contains(suite.T(), text, "01.10.2026      100    2.4   14.3    0.0  тренировка\n")
+	assert.Contains(suite.T(), text, "02.10.2026        0    2.3   12.2  -11.9  отдых\n")
+}
+
+func (suite *TrainingLoadTestSuite) TestRestAndMissedLoad() {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	build := func(rest bool) []Day {
+		r := report.Report{From: from}
+		for i := range 3 {
+			r.Days = append(r.Days, report.Day{Date: from.AddDate(0, 0, i)})
+		}
+		r.Days[0].Trainings = []report.Training{{Result: session(spentcalories.SegmentResult{
+			Segment: spentcalories.Segment{Type: spentcalories.RunningType, Duration: time.Hour},
+			Speed:   12,
+		})}}
+		if rest {
+			r.Days[1].Rest = &recovery.Rest{Note: "по плану"}
+		}
+		return Model(r, DefaultThresholds())
+	}
+
+	rested, missed := build(true), build(false)
+	assert.Equal(suite.T(), StatusRest, rested[1].Status)
+	assert.Equal(suite.T(), StatusMissed, missed[1].Status)
+
+	// Нагрузка, форма и усталость от плана не зависят: различается только вид дня.
+	missed[1].Status = StatusRest
+	assert.Equal(suite.T(), rested, missed)
+}
@@ -0,0 +1,51 @@
+package zone
+
+import (
+	"time"
+	// Встроенная база часовых поясов: пояса IANA доступны и там, где её нет в системе.
+	_ "time/tzdata"
+
+	"github.com/Yandex-Practicum/tracker/internal/parseerr"
+)
+
+// Load возвращает часовой пояс IANA по имени, например "Europe/Berlin".
+// Пустое имя означает местный часовой пояс.
+func Load(name string) (*time.Location, error) {
+	if name == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, parseerr.New("tz", parseerr.KindUnknown, "неизвестный часовой пояс %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// Date возвращает начало календарного дня y-m-d в поясе loc. Выход месяца
+// и дня за границы нормализуется, как в time.Date. Если полночь пропущена
+// при переходе на летнее время, день начинается с первого момента после перехода.
+// Из-за переходов сутки длятся 23 или 25 часов, поэтому следующий день —
+// Date(y, m, d+1, loc), а не начало дня плюс 24 часа.
+func Date(y int, m time.Month, d int, loc *time.Location) time.Time {
+	// Полдень не попадает на переходы, поэтому нормализует дату без сдвига.
+	y, m, d = time.Date(y, m, d, 12, 0, 0, 0, loc).Date()
+	t := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	// Для пропущенной полуночи time.Date возвращает момент до перехода, то есть накануне.
+	for t.Day() != d {
+		t = t.Add(time.Hour)
+	}
+	return t
+}
+
+// StartOfDay возвращает начало календарного дня момента t в поясе loc.
+func StartOfDay(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return Date(y, m, d, loc)
+}
+
+// WeekStart возвращает начало понедельника недели, содержащей t, в поясе t.
+func WeekStart(t time.Time) time.Time {
+	y, m, d := t.Date()
+	offset := (int(t.Weekday()) + 6) % 7
+	return Date(y, m, d-offset, t.Location())
+}
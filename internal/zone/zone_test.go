@@ -0,0 +1,65 @@
+package zone
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ZoneTestSuite struct {
+	suite.Suite
+}
+
+func TestZoneSuite(t *testing.T) {
+	suite.Run(t, new(ZoneTestSuite))
+}
+
+func (suite *ZoneTestSuite) load(name string) *time.Location {
+	loc, err := Load(name)
+	suite.Require().NoError(err)
+	return loc
+}
+
+func (suite *ZoneTestSuite) TestLoad() {
+	assert.Equal(suite.T(), time.Local, suite.load(""))
+	assert.Equal(suite.T(), "Europe/Berlin", suite.load("Europe/Berlin").String())
+
+	_, err := Load("Mars/Olympus")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ZoneTestSuite) TestDayLength() {
+	berlin := suite.load("Europe/Berlin")
+
+	// Переход на летнее время: сутки 23 часа, на зимнее — 25.
+	spring := Date(2026, 3, 29, berlin)
+	assert.Equal(suite.T(), 23*time.Hour, Date(2026, 3, 30, berlin).Sub(spring))
+	autumn := Date(2026, 10, 25, berlin)
+	assert.Equal(suite.T(), 25*time.Hour, Date(2026, 10, 26, berlin).Sub(autumn))
+
+	assert.Equal(suite.T(), Date(2026, 11, 1, berlin), Date(2026, 10, 32, berlin))
+	assert.Equal(suite.T(), autumn, StartOfDay(autumn.Add(24*time.Hour+30*time.Minute), berlin),
+		"23:30 по зимнему времени ещё относится к дню перехода")
+}
+
+func (suite *ZoneTestSuite) TestSkippedMidnight() {
+	// В 2018 году в Сан-Паулу часы перевели с 00:00 на 01:00 4 ноября.
+	saoPaulo := suite.load("America/Sao_Paulo")
+	got := Date(2018, 11, 4, saoPaulo)
+	assert.Equal(suite.T(), "2018-11-04 01:00 -02", got.Format("2006-01-02 15:04 -07"))
+	assert.Equal(suite.T(), 23*time.Hour, Date(2018, 11, 5, saoPaulo).Sub(got))
+}
+
+func (suite *ZoneTestSuite) TestWeekStart() {
+	monday := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	assert.Equal(suite.T(), monday, WeekStart(time.Date(2026, 10, 18, 23, 0, 0, 0, time.UTC)))
+	assert.Equal(suite.T(), monday, WeekStart(monday.Add(time.Hour)))
+
+	// Неделя с переходом на зимнее время длится 169 часов.
+	berlin := suite.load("Europe/Berlin")
+	week := WeekStart(Date(2026, 10, 25, berlin))
+	assert.Equal(suite.T(), Date(2026, 10, 19, berlin), week)
+	assert.Equal(suite.T(), week, WeekStart(week.Add(7*24*time.Hour)))
+}